@@ -0,0 +1,436 @@
+// Package cache provides bounded in-memory caches with LRU or LFU eviction,
+// expiry by TTL or idle time, and loaders collapsing concurrent loads of the same key.
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-board/std/collections/list"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/optional"
+	"github.com/go-board/std/tuple"
+)
+
+// Clock tells the current time, it's used to decide expiry.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to [Clock].
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time { return f() }
+
+// SystemClock returns a [Clock] reading the wall clock.
+func SystemClock() Clock { return ClockFunc(time.Now) }
+
+// Cause tells why an entry left the cache.
+type Cause int
+
+const (
+	// CauseCapacity means the entry was evicted to keep the cache within its capacity.
+	CauseCapacity Cause = iota
+	// CauseExpired means the entry lived longer than TTL or was idle longer than IdleTimeout.
+	CauseExpired
+	// CauseReplaced means the value was replaced by a newer one of the same key.
+	CauseReplaced
+	// CauseRemoved means the entry was removed explicitly.
+	CauseRemoved
+)
+
+func (c Cause) String() string {
+	switch c {
+	case CauseCapacity:
+		return "capacity"
+	case CauseExpired:
+		return "expired"
+	case CauseReplaced:
+		return "replaced"
+	case CauseRemoved:
+		return "removed"
+	}
+	return fmt.Sprintf("Cause(%d)", int(c))
+}
+
+// ErrLoaderPanicked is returned to callers waiting on a load whose loader panicked.
+var ErrLoaderPanicked = errors.New("cache: loader panicked")
+
+// Config configures a [Cache], the zero value is an unbounded cache without expiry.
+type Config[K comparable, V any] struct {
+	// Capacity is the max total weight of entries, zero means unbounded.
+	Capacity int64
+	// Weigher computes the weight of an entry, every entry weighs 1 if it's nil.
+	// A negative weight counts as zero.
+	Weigher func(key K, value V) int64
+	// TTL expires an entry once this long has passed since it was written, zero means never.
+	TTL time.Duration
+	// IdleTimeout expires an entry once this long has passed since it was last read or written,
+	// zero means never.
+	IdleTimeout time.Duration
+	// OnEvict is called after an entry left the cache, outside of any lock.
+	OnEvict func(key K, value V, cause Cause)
+	// Clock tells the current time, [SystemClock] is used if it's nil.
+	Clock Clock
+}
+
+// Stats is a snapshot of cache statistics.
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Loads       uint64
+	LoadErrors  uint64
+	Evictions   uint64
+	Expirations uint64
+}
+
+// HitRate returns the ratio of hits to lookups, or 0 if there was no lookup.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+type entry[K comparable, V any] struct {
+	key      K
+	value    V
+	weight   int64
+	written  time.Time
+	accessed time.Time
+	node     *list.Node[*entry[K, V]]
+	bucket   *list.Node[*lfuBucket[K, V]]
+}
+
+type eviction[K comparable, V any] struct {
+	key   K
+	value V
+	cause Cause
+}
+
+// call is an in-flight load.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Cache is a bounded cache safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	cfg     Config[K, V]
+	mu      sync.Mutex
+	items   map[K]*entry[K, V]
+	policy  policy[K, V]
+	weight  int64
+	stats   Stats
+	loading map[K]*call[V]
+}
+
+func newCache[K comparable, V any](cfg Config[K, V], p policy[K, V]) *Cache[K, V] {
+	if cfg.Clock == nil {
+		cfg.Clock = SystemClock()
+	}
+	return &Cache[K, V]{
+		cfg:     cfg,
+		items:   make(map[K]*entry[K, V]),
+		policy:  p,
+		loading: make(map[K]*call[V]),
+	}
+}
+
+// NewLRU creates a [Cache] which evicts the least recently used entry first.
+func NewLRU[K comparable, V any](cfg Config[K, V]) *Cache[K, V] {
+	return newCache[K, V](cfg, &lru[K, V]{})
+}
+
+// NewLFU creates a [Cache] which evicts the least frequently used entry first,
+// the least recently used one among entries with the same frequency.
+func NewLFU[K comparable, V any](cfg Config[K, V]) *Cache[K, V] {
+	return newCache[K, V](cfg, &lfu[K, V]{})
+}
+
+func (self *Cache[K, V]) expired(e *entry[K, V], now time.Time) bool {
+	if self.cfg.TTL > 0 && now.Sub(e.written) >= self.cfg.TTL {
+		return true
+	}
+	return self.cfg.IdleTimeout > 0 && now.Sub(e.accessed) >= self.cfg.IdleTimeout
+}
+
+func (self *Cache[K, V]) weigh(key K, value V) int64 {
+	if self.cfg.Weigher == nil {
+		return 1
+	}
+	if w := self.cfg.Weigher(key, value); w > 0 {
+		return w
+	}
+	return 0
+}
+
+// unlink removes e and records the eviction, caller must hold the lock.
+func (self *Cache[K, V]) unlink(e *entry[K, V], cause Cause, evicted []eviction[K, V]) []eviction[K, V] {
+	delete(self.items, e.key)
+	self.policy.remove(e)
+	self.weight -= e.weight
+	switch cause {
+	case CauseCapacity:
+		self.stats.Evictions++
+	case CauseExpired:
+		self.stats.Expirations++
+	}
+	return append(evicted, eviction[K, V]{key: e.key, value: e.value, cause: cause})
+}
+
+// notify calls OnEvict for evicted entries, caller must not hold the lock.
+func (self *Cache[K, V]) notify(evicted []eviction[K, V]) {
+	if self.cfg.OnEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		self.cfg.OnEvict(e.key, e.value, e.cause)
+	}
+}
+
+// lookup returns the live entry of key, an expired entry is removed.
+func (self *Cache[K, V]) lookup(key K, now time.Time, evicted []eviction[K, V]) (*entry[K, V], []eviction[K, V]) {
+	e, ok := self.items[key]
+	if !ok {
+		return nil, evicted
+	}
+	if self.expired(e, now) {
+		return nil, self.unlink(e, CauseExpired, evicted)
+	}
+	return e, evicted
+}
+
+func (self *Cache[K, V]) get(key K) (optional.Optional[V], []eviction[K, V]) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	now := self.cfg.Clock.Now()
+	e, evicted := self.lookup(key, now, nil)
+	if e == nil {
+		self.stats.Misses++
+		return optional.None[V](), evicted
+	}
+	self.stats.Hits++
+	e.accessed = now
+	self.policy.access(e)
+	return optional.Some(e.value), evicted
+}
+
+// Get returns the value of key, or None if it's absent or expired.
+//
+// A successful Get counts as an access of the entry.
+func (self *Cache[K, V]) Get(key K) optional.Optional[V] {
+	v, evicted := self.get(key)
+	self.notify(evicted)
+	return v
+}
+
+// Peek returns the value of key without counting as an access or updating stats.
+func (self *Cache[K, V]) Peek(key K) optional.Optional[V] {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	e, ok := self.items[key]
+	if !ok || self.expired(e, self.cfg.Clock.Now()) {
+		return optional.None[V]()
+	}
+	return optional.Some(e.value)
+}
+
+// ContainsKey tests whether key is present and not expired, without counting as an access.
+func (self *Cache[K, V]) ContainsKey(key K) bool {
+	return self.Peek(key).IsSome()
+}
+
+func (self *Cache[K, V]) insert(key K, value V) []eviction[K, V] {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	now := self.cfg.Clock.Now()
+	var evicted []eviction[K, V]
+	if e, ok := self.items[key]; ok {
+		return self.update(e, value, now)
+	}
+	e := &entry[K, V]{key: key, value: value, weight: self.weigh(key, value), written: now, accessed: now}
+	if self.cfg.Capacity > 0 {
+		if e.weight > self.cfg.Capacity {
+			// it never fits, reject it rather than flushing the whole cache.
+			self.stats.Evictions++
+			return append(evicted, eviction[K, V]{key: key, value: value, cause: CauseCapacity})
+		}
+		// make room before adding, so a new entry is never its own victim.
+		for self.weight+e.weight > self.cfg.Capacity {
+			evicted = self.unlink(self.policy.victim(nil), CauseCapacity, evicted)
+		}
+	}
+	self.items[key] = e
+	self.policy.add(e)
+	self.weight += e.weight
+	return evicted
+}
+
+// update replaces the value of e in place, so it keeps its place in the policy
+// like an access does, e.g. its LFU frequency. Caller must hold the lock.
+func (self *Cache[K, V]) update(e *entry[K, V], value V, now time.Time) []eviction[K, V] {
+	weight := self.weigh(e.key, value)
+	if self.cfg.Capacity > 0 && weight > self.cfg.Capacity {
+		evicted := self.unlink(e, CauseReplaced, nil)
+		self.stats.Evictions++
+		return append(evicted, eviction[K, V]{key: e.key, value: value, cause: CauseCapacity})
+	}
+	evicted := []eviction[K, V]{{key: e.key, value: e.value, cause: CauseReplaced}}
+	self.weight += weight - e.weight
+	e.value, e.weight, e.written, e.accessed = value, weight, now, now
+	self.policy.access(e)
+	// the updated entry itself fits, so others are evicted until the cache is within capacity.
+	for self.cfg.Capacity > 0 && self.weight > self.cfg.Capacity {
+		evicted = self.unlink(self.policy.victim(e), CauseCapacity, evicted)
+	}
+	return evicted
+}
+
+// Insert inserts or replaces the value of key, evicting entries to make room for it.
+//
+// An entry weighing more than the capacity is evicted at once, leaving other entries untouched.
+func (self *Cache[K, V]) Insert(key K, value V) {
+	self.notify(self.insert(key, value))
+}
+
+// Remove removes key, returns its value if it's present and not expired.
+func (self *Cache[K, V]) Remove(key K) optional.Optional[V] {
+	self.mu.Lock()
+	e, evicted := self.lookup(key, self.cfg.Clock.Now(), nil)
+	if e != nil {
+		evicted = self.unlink(e, CauseRemoved, evicted)
+	}
+	self.mu.Unlock()
+	self.notify(evicted)
+	if e == nil {
+		return optional.None[V]()
+	}
+	return optional.Some(e.value)
+}
+
+// GetOrLoad returns the value of key, loads and inserts it by loader if it's absent or expired.
+//
+// Concurrent calls for the same key share a single load, a failed load is not cached.
+// If loader panics, the panic propagates to the caller running it
+// and other callers waiting for it get [ErrLoaderPanicked].
+func (self *Cache[K, V]) GetOrLoad(key K, loader func(K) (V, error)) (V, error) {
+	self.mu.Lock()
+	now := self.cfg.Clock.Now()
+	e, evicted := self.lookup(key, now, nil)
+	if e != nil {
+		self.stats.Hits++
+		e.accessed = now
+		self.policy.access(e)
+		self.mu.Unlock()
+		self.notify(evicted)
+		return e.value, nil
+	}
+	self.stats.Misses++
+	if c, ok := self.loading[key]; ok {
+		self.mu.Unlock()
+		self.notify(evicted)
+		<-c.done
+		return c.value, c.err
+	}
+	c := &call[V]{done: make(chan struct{}), err: ErrLoaderPanicked}
+	self.loading[key] = c
+	self.mu.Unlock()
+	self.notify(evicted)
+
+	defer func() {
+		self.mu.Lock()
+		delete(self.loading, key)
+		self.stats.Loads++
+		if c.err != nil {
+			self.stats.LoadErrors++
+		}
+		self.mu.Unlock()
+		close(c.done)
+	}()
+	c.value, c.err = loader(key)
+	if c.err == nil {
+		self.Insert(key, c.value)
+	}
+	return c.value, c.err
+}
+
+// Purge removes all expired entries.
+func (self *Cache[K, V]) Purge() {
+	self.mu.Lock()
+	now := self.cfg.Clock.Now()
+	var expired []*entry[K, V]
+	self.policy.entries(func(e *entry[K, V]) {
+		if self.expired(e, now) {
+			expired = append(expired, e)
+		}
+	})
+	var evicted []eviction[K, V]
+	for _, e := range expired {
+		evicted = self.unlink(e, CauseExpired, evicted)
+	}
+	self.mu.Unlock()
+	self.notify(evicted)
+}
+
+// Clear removes all entries, reported with [CauseRemoved].
+func (self *Cache[K, V]) Clear() {
+	self.mu.Lock()
+	var all []*entry[K, V]
+	self.policy.entries(func(e *entry[K, V]) { all = append(all, e) })
+	var evicted []eviction[K, V]
+	for _, e := range all {
+		evicted = self.unlink(e, CauseRemoved, evicted)
+	}
+	self.mu.Unlock()
+	self.notify(evicted)
+}
+
+// Len returns the number of entries, including expired ones not removed yet.
+func (self *Cache[K, V]) Len() int {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return len(self.items)
+}
+
+// Weight returns the total weight of entries.
+func (self *Cache[K, V]) Weight() int64 {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.weight
+}
+
+// Stats returns a snapshot of statistics.
+func (self *Cache[K, V]) Stats() Stats {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.stats
+}
+
+// Iter returns an iterator over a snapshot of live entries,
+// from the first to be evicted to the last.
+//
+// Iterating doesn't count as an access.
+func (self *Cache[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		self.mu.Lock()
+		now := self.cfg.Clock.Now()
+		// copied under the lock, entries are updated in place once it's released.
+		var live []tuple.Pair[K, V]
+		self.policy.entries(func(e *entry[K, V]) {
+			if !self.expired(e, now) {
+				live = append(live, tuple.MakePair(e.key, e.value))
+			}
+		})
+		self.mu.Unlock()
+		for _, p := range live {
+			if !yield(p.First(), p.Second()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package cache_test
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/cache"
+)
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time          { return c.now }
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func keys[K comparable, V any](c *cache.Cache[K, V]) []K {
+	var ks []K
+	c.Iter()(func(k K, _ V) bool { ks = append(ks, k); return true })
+	return ks
+}
+
+func TestLRU(t *testing.T) {
+	var evicted []string
+	c := cache.NewLRU(cache.Config[string, int]{
+		Capacity: 2,
+		OnEvict:  func(k string, _ int, cause cache.Cause) { evicted = append(evicted, k+":"+cause.String()) },
+	})
+	c.Insert("a", 1)
+	c.Insert("b", 2)
+	qt.Assert(t, c.Get("a").Value(), qt.Equals, 1)
+	c.Insert("c", 3)
+	qt.Assert(t, c.Get("b").IsNone(), qt.IsTrue)
+	qt.Assert(t, keys(c), qt.DeepEquals, []string{"a", "c"})
+	c.Insert("a", 10)
+	qt.Assert(t, c.Remove("c").Value(), qt.Equals, 3)
+	qt.Assert(t, c.Remove("c").IsNone(), qt.IsTrue)
+	qt.Assert(t, evicted, qt.DeepEquals, []string{"b:capacity", "a:replaced", "c:removed"})
+	qt.Assert(t, c.Stats(), qt.DeepEquals, cache.Stats{Hits: 1, Misses: 1, Evictions: 1})
+	qt.Assert(t, c.Stats().HitRate(), qt.Equals, 0.5)
+	qt.Assert(t, c.Len(), qt.Equals, 1)
+}
+
+func TestLFU(t *testing.T) {
+	c := cache.NewLFU(cache.Config[string, int]{Capacity: 3})
+	c.Insert("a", 1)
+	c.Insert("b", 2)
+	c.Insert("c", 3)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+	c.Get("c")
+	// a:3, b:2, c:2, c is more recent than b.
+	c.Insert("d", 4)
+	qt.Assert(t, c.ContainsKey("b"), qt.IsFalse)
+	qt.Assert(t, keys(c), qt.DeepEquals, []string{"d", "c", "a"})
+	c.Insert("e", 5)
+	qt.Assert(t, keys(c), qt.DeepEquals, []string{"e", "c", "a"})
+	c.Remove("c")
+	c.Get("e")
+	qt.Assert(t, keys(c), qt.DeepEquals, []string{"e", "a"})
+}
+
+func TestWeight(t *testing.T) {
+	c := cache.NewLRU(cache.Config[string, string]{
+		Capacity: 10,
+		Weigher:  func(_ string, v string) int64 { return int64(len(v)) },
+	})
+	c.Insert("a", "aaaa")
+	c.Insert("b", "bbbb")
+	qt.Assert(t, c.Weight(), qt.Equals, int64(8))
+	c.Insert("c", "ccc")
+	qt.Assert(t, keys(c), qt.DeepEquals, []string{"b", "c"})
+	qt.Assert(t, c.Weight(), qt.Equals, int64(7))
+	c.Insert("d", "dddddddddddd")
+	qt.Assert(t, keys(c), qt.DeepEquals, []string{"b", "c"})
+	qt.Assert(t, c.Stats().Evictions, qt.Equals, uint64(2))
+}
+
+func TestWeight_Update(t *testing.T) {
+	c := cache.NewLRU(cache.Config[string, string]{
+		Capacity: 10,
+		Weigher:  func(_ string, v string) int64 { return int64(len(v)) - 2 },
+	})
+	c.Insert("a", "")
+	qt.Assert(t, c.Weight(), qt.Equals, int64(0))
+	c.Insert("b", "bbbbbb")
+	c.Insert("c", "cccccc")
+	c.Insert("a", "aaaaaa")
+	// a grows to 4 and stays the most recent, b makes room for it.
+	qt.Assert(t, keys(c), qt.DeepEquals, []string{"c", "a"})
+	qt.Assert(t, c.Weight(), qt.Equals, int64(8))
+}
+
+func TestLFU_UpdateKeepsFrequency(t *testing.T) {
+	c := cache.NewLFU(cache.Config[string, int]{Capacity: 2})
+	c.Insert("a", 1)
+	c.Get("a")
+	c.Get("a")
+	c.Insert("b", 2)
+	c.Get("b")
+	c.Insert("a", 10)
+	// a:4 after the update, b:2, so b is evicted.
+	c.Insert("c", 3)
+	qt.Assert(t, keys(c), qt.DeepEquals, []string{"c", "a"})
+	qt.Assert(t, c.Get("a").Value(), qt.Equals, 10)
+}
+
+func TestExpiry(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var expired []string
+	c := cache.NewLRU(cache.Config[string, int]{
+		TTL:         time.Minute,
+		IdleTimeout: 20 * time.Second,
+		Clock:       clock,
+		OnEvict: func(k string, _ int, cause cache.Cause) {
+			if cause == cache.CauseExpired {
+				expired = append(expired, k)
+			}
+		},
+	})
+	c.Insert("a", 1)
+	c.Insert("b", 2)
+	for i := 0; i < 5; i++ {
+		clock.Advance(10 * time.Second)
+		qt.Assert(t, c.Get("a").IsSome(), qt.IsTrue)
+	}
+	qt.Assert(t, c.Peek("b").IsNone(), qt.IsTrue)
+	qt.Assert(t, c.Len(), qt.Equals, 2)
+	c.Purge()
+	qt.Assert(t, expired, qt.DeepEquals, []string{"b"})
+	clock.Advance(10 * time.Second)
+	qt.Assert(t, c.Get("a").IsNone(), qt.IsTrue)
+	qt.Assert(t, expired, qt.DeepEquals, []string{"b", "a"})
+	qt.Assert(t, c.Stats().Expirations, qt.Equals, uint64(2))
+	qt.Assert(t, c.Len(), qt.Equals, 0)
+}
+
+func TestGetOrLoad(t *testing.T) {
+	c := cache.NewLRU(cache.Config[int, int]{})
+	var loads atomic.Int32
+	release := make(chan struct{})
+	loader := func(k int) (int, error) {
+		loads.Add(1)
+		<-release
+		return k * 2, nil
+	}
+	var wg sync.WaitGroup
+	results := make([]int, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := c.GetOrLoad(21, loader)
+			qt.Check(t, err, qt.IsNil)
+			results[i] = v
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	qt.Assert(t, loads.Load(), qt.Equals, int32(1))
+	for _, v := range results {
+		qt.Assert(t, v, qt.Equals, 42)
+	}
+	v, err := c.GetOrLoad(21, loader)
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, v, qt.Equals, 42)
+	qt.Assert(t, loads.Load(), qt.Equals, int32(1))
+	qt.Assert(t, c.Stats().Loads, qt.Equals, uint64(1))
+
+	boom := errors.New("boom")
+	_, err = c.GetOrLoad(1, func(int) (int, error) { return 0, boom })
+	qt.Assert(t, err, qt.Equals, boom)
+	qt.Assert(t, c.ContainsKey(1), qt.IsFalse)
+	qt.Assert(t, c.Stats().LoadErrors, qt.Equals, uint64(1))
+
+	qt.Assert(t, func() { c.GetOrLoad(2, func(int) (int, error) { panic("oops") }) }, qt.PanicMatches, "oops")
+	v, err = c.GetOrLoad(2, func(k int) (int, error) { return k, nil })
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, v, qt.Equals, 2)
+}
+
+func TestIter_ConcurrentInsert(t *testing.T) {
+	c := cache.NewLRU(cache.Config[int, int]{Capacity: 4})
+	c.Insert(0, 0)
+	c.Insert(1, 0)
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				c.Insert(1, i)
+			}
+		}
+	}()
+	for i := 0; i < 5; i++ {
+		c.Iter()(func(k, v int) bool {
+			// let the writer run between taking the snapshot and reading it.
+			runtime.Gosched()
+			qt.Assert(t, k == 1 || v == 0, qt.IsTrue)
+			return true
+		})
+	}
+	close(stop)
+	wg.Wait()
+}
+
+func TestClear(t *testing.T) {
+	var removed int
+	c := cache.NewLFU(cache.Config[int, int]{OnEvict: func(int, int, cache.Cause) { removed++ }})
+	for i := 0; i < 5; i++ {
+		c.Insert(i, i)
+	}
+	c.Clear()
+	qt.Assert(t, removed, qt.Equals, 5)
+	qt.Assert(t, c.Len(), qt.Equals, 0)
+	qt.Assert(t, keys(c), qt.HasLen, 0)
+}
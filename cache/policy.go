@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"github.com/go-board/std/collections/list"
+)
+
+// policy decides which entry to evict when the cache is over capacity.
+type policy[K comparable, V any] interface {
+	add(e *entry[K, V])
+	access(e *entry[K, V])
+	remove(e *entry[K, V])
+	// victim returns the entry to evict first other than except, or nil if there is none.
+	victim(except *entry[K, V]) *entry[K, V]
+	// entries calls f for each entry, from the first to be evicted to the last.
+	entries(f func(e *entry[K, V]))
+}
+
+// lru evicts the least recently used entry.
+type lru[K comparable, V any] struct {
+	order list.List[*entry[K, V]]
+}
+
+func (p *lru[K, V]) add(e *entry[K, V])    { e.node = p.order.PushBack(e) }
+func (p *lru[K, V]) access(e *entry[K, V]) { p.order.MoveToBack(e.node) }
+func (p *lru[K, V]) remove(e *entry[K, V]) { p.order.Remove(e.node) }
+
+func (p *lru[K, V]) victim(except *entry[K, V]) *entry[K, V] {
+	n := p.order.Front()
+	if n != nil && n.Value == except {
+		n = n.Next()
+	}
+	if n != nil {
+		return n.Value
+	}
+	return nil
+}
+
+func (p *lru[K, V]) entries(f func(e *entry[K, V])) {
+	for n := p.order.Front(); n != nil; n = n.Next() {
+		f(n.Value)
+	}
+}
+
+// lfuBucket holds entries with the same access frequency, in LRU order.
+type lfuBucket[K comparable, V any] struct {
+	freq    uint64
+	entries list.List[*entry[K, V]]
+}
+
+// lfu evicts the least frequently used entry, ties are broken by recency.
+//
+// Buckets are kept in ascending order of frequency, so every operation is O(1).
+type lfu[K comparable, V any] struct {
+	buckets list.List[*lfuBucket[K, V]]
+}
+
+// place puts e into the bucket of freq right after the given bucket node,
+// at may be nil to place at the front.
+func (p *lfu[K, V]) place(e *entry[K, V], freq uint64, at *list.Node[*lfuBucket[K, V]]) {
+	var next *list.Node[*lfuBucket[K, V]]
+	if at == nil {
+		next = p.buckets.Front()
+	} else {
+		next = at.Next()
+	}
+	if next == nil || next.Value.freq != freq {
+		b := &lfuBucket[K, V]{freq: freq}
+		if at == nil {
+			next = p.buckets.PushFront(b)
+		} else {
+			next = p.buckets.InsertAfter(b, at)
+		}
+	}
+	e.bucket = next
+	e.node = next.Value.entries.PushBack(e)
+}
+
+func (p *lfu[K, V]) add(e *entry[K, V]) { p.place(e, 1, nil) }
+
+func (p *lfu[K, V]) access(e *entry[K, V]) {
+	b := e.bucket
+	b.Value.entries.Remove(e.node)
+	p.place(e, b.Value.freq+1, b)
+	if b.Value.entries.IsEmpty() {
+		p.buckets.Remove(b)
+	}
+}
+
+func (p *lfu[K, V]) remove(e *entry[K, V]) {
+	b := e.bucket
+	b.Value.entries.Remove(e.node)
+	if b.Value.entries.IsEmpty() {
+		p.buckets.Remove(b)
+	}
+	e.bucket, e.node = nil, nil
+}
+
+func (p *lfu[K, V]) victim(except *entry[K, V]) *entry[K, V] {
+	b := p.buckets.Front()
+	if b == nil {
+		return nil
+	}
+	n := b.Value.entries.Front()
+	if n.Value == except {
+		if n = n.Next(); n == nil {
+			if b = b.Next(); b == nil {
+				return nil
+			}
+			n = b.Value.entries.Front()
+		}
+	}
+	return n.Value
+}
+
+func (p *lfu[K, V]) entries(f func(e *entry[K, V])) {
+	for b := p.buckets.Front(); b != nil; b = b.Next() {
+		for n := b.Value.entries.Front(); n != nil; n = n.Next() {
+			f(n.Value)
+		}
+	}
+}
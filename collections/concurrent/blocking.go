@@ -0,0 +1,196 @@
+// Package concurrent provides collections safe for concurrent use by multiple goroutines.
+package concurrent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-board/std/collections/queue"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/optional"
+)
+
+// ErrClosed is returned when putting into a closed queue,
+// or taking from a closed and empty queue.
+var ErrClosed = errors.New("concurrent: queue closed")
+
+// BlockingQueue is a bounded FIFO queue, putting blocks while it's full
+// and taking blocks while it's empty.
+type BlockingQueue[E any] struct {
+	mu     sync.Mutex
+	items  queue.Deque[E]
+	cap    int
+	closed bool
+	// notEmpty and notFull are created by waiters, and closed to wake them all up.
+	notEmpty chan struct{}
+	notFull  chan struct{}
+}
+
+// NewBlockingQueue creates a [BlockingQueue] holds at most n elements.
+//
+// If n less than 1, it's set to 1.
+func NewBlockingQueue[E any](n int) *BlockingQueue[E] {
+	if n < 1 {
+		n = 1
+	}
+	return &BlockingQueue[E]{items: *queue.NewDequeWithCapacity[E](n), cap: n}
+}
+
+func wake(ch *chan struct{}) {
+	if *ch != nil {
+		close(*ch)
+		*ch = nil
+	}
+}
+
+func wait(ch *chan struct{}) <-chan struct{} {
+	if *ch == nil {
+		*ch = make(chan struct{})
+	}
+	return *ch
+}
+
+// TryPush appends an element without blocking,
+// returns false if [BlockingQueue] is full or closed.
+func (q *BlockingQueue[E]) TryPush(e E) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed || q.items.Len() >= q.cap {
+		return false
+	}
+	q.items.PushBack(e)
+	wake(&q.notEmpty)
+	return true
+}
+
+// TryPop removes the first element without blocking,
+// returns None if [BlockingQueue] is empty.
+func (q *BlockingQueue[E]) TryPop() optional.Optional[E] {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	e := q.items.PopFront()
+	if e.IsSome() {
+		wake(&q.notFull)
+	}
+	return e
+}
+
+// Put appends an element, blocks until there is room for it.
+//
+// It returns the context error if ctx is done first, or [ErrClosed] if [BlockingQueue] is closed.
+func (q *BlockingQueue[E]) Put(ctx context.Context, e E) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+		if q.items.Len() < q.cap {
+			q.items.PushBack(e)
+			wake(&q.notEmpty)
+			q.mu.Unlock()
+			return nil
+		}
+		ch := wait(&q.notFull)
+		q.mu.Unlock()
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Take removes the first element, blocks until there is one.
+//
+// It returns the context error if ctx is done first,
+// or [ErrClosed] if [BlockingQueue] is closed and empty.
+func (q *BlockingQueue[E]) Take(ctx context.Context) (E, error) {
+	for {
+		q.mu.Lock()
+		if e := q.items.PopFront(); e.IsSome() {
+			wake(&q.notFull)
+			q.mu.Unlock()
+			return e.Value(), nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			var zero E
+			return zero, ErrClosed
+		}
+		ch := wait(&q.notEmpty)
+		q.mu.Unlock()
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			var zero E
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// PutTimeout is like [BlockingQueue.Put], but gives up after duration,
+// returns whether the element is put.
+func (q *BlockingQueue[E]) PutTimeout(e E, duration time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	return q.Put(ctx, e) == nil
+}
+
+// TakeTimeout is like [BlockingQueue.Take], but gives up after duration.
+func (q *BlockingQueue[E]) TakeTimeout(duration time.Duration) (E, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+	e, err := q.Take(ctx)
+	return e, err == nil
+}
+
+// Close closes [BlockingQueue], blocked and later puts fail with [ErrClosed],
+// takes keep returning remaining elements until it's empty.
+func (q *BlockingQueue[E]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	wake(&q.notEmpty)
+	wake(&q.notFull)
+}
+
+// Len returns the number of elements in [BlockingQueue].
+func (q *BlockingQueue[E]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+// Cap returns the max number of elements [BlockingQueue] can hold.
+func (q *BlockingQueue[E]) Cap() int { return q.cap }
+
+// Drain creates an [iter.Seq] which pops elements until [BlockingQueue] is empty, without blocking.
+func (q *BlockingQueue[E]) Drain() iter.Seq[E] {
+	return drain(q.TryPop)
+}
+
+// Stream creates an [iter.Seq] which takes elements, blocking while [BlockingQueue] is empty,
+// until ctx is done or [BlockingQueue] is closed and empty.
+func (q *BlockingQueue[E]) Stream(ctx context.Context) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for {
+			e, err := q.Take(ctx)
+			if err != nil || !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+func drain[E any](pop func() optional.Optional[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for e := pop(); e.IsSome(); e = pop() {
+			if !yield(e.Value()) {
+				return
+			}
+		}
+	}
+}
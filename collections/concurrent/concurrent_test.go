@@ -0,0 +1,162 @@
+package concurrent_test
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/collections/concurrent"
+	"github.com/go-board/std/iter/collector"
+)
+
+func TestBlockingQueue_Try(t *testing.T) {
+	q := concurrent.NewBlockingQueue[int](2)
+	qt.Assert(t, q.Cap(), qt.Equals, 2)
+	qt.Assert(t, q.TryPop().IsNone(), qt.IsTrue)
+	qt.Assert(t, q.TryPush(1), qt.IsTrue)
+	qt.Assert(t, q.TryPush(2), qt.IsTrue)
+	qt.Assert(t, q.TryPush(3), qt.IsFalse)
+	qt.Assert(t, q.Len(), qt.Equals, 2)
+	qt.Assert(t, collector.Collect(q.Drain(), collector.ToSlice[int]()), qt.DeepEquals, []int{1, 2})
+	qt.Assert(t, q.Len(), qt.Equals, 0)
+}
+
+func TestBlockingQueue_Blocking(t *testing.T) {
+	q := concurrent.NewBlockingQueue[int](1)
+	ctx := context.Background()
+	qt.Assert(t, q.Put(ctx, 1), qt.IsNil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		qt.Check(t, q.Put(ctx, 2), qt.IsNil)
+	}()
+	select {
+	case <-done:
+		t.Fatal("put should block while full")
+	case <-time.After(20 * time.Millisecond):
+	}
+	e, err := q.Take(ctx)
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, e, qt.Equals, 1)
+	<-done
+	e, err = q.Take(ctx)
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, e, qt.Equals, 2)
+}
+
+func TestBlockingQueue_Cancel(t *testing.T) {
+	q := concurrent.NewBlockingQueue[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := q.Take(ctx)
+	qt.Assert(t, err, qt.Equals, context.DeadlineExceeded)
+
+	_, ok := q.TakeTimeout(time.Millisecond)
+	qt.Assert(t, ok, qt.IsFalse)
+	qt.Assert(t, q.PutTimeout(1, time.Millisecond), qt.IsTrue)
+	qt.Assert(t, q.PutTimeout(2, time.Millisecond), qt.IsFalse)
+	e, ok := q.TakeTimeout(time.Millisecond)
+	qt.Assert(t, ok, qt.IsTrue)
+	qt.Assert(t, e, qt.Equals, 1)
+}
+
+func TestBlockingQueue_Close(t *testing.T) {
+	q := concurrent.NewBlockingQueue[int](4)
+	ctx := context.Background()
+	var got []int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		got = collector.Collect(q.Stream(ctx), collector.ToSlice[int]())
+	}()
+	for i := 0; i < 10; i++ {
+		qt.Assert(t, q.Put(ctx, i), qt.IsNil)
+	}
+	q.Close()
+	<-done
+	qt.Assert(t, got, qt.DeepEquals, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	qt.Assert(t, q.Put(ctx, 10), qt.Equals, concurrent.ErrClosed)
+	_, err := q.Take(ctx)
+	qt.Assert(t, err, qt.Equals, concurrent.ErrClosed)
+}
+
+func TestMPMCQueue_Try(t *testing.T) {
+	q := concurrent.NewMPMCQueue[int](3)
+	qt.Assert(t, q.Cap(), qt.Equals, 4)
+	qt.Assert(t, q.TryPop().IsNone(), qt.IsTrue)
+	for i := 0; i < 4; i++ {
+		qt.Assert(t, q.TryPush(i), qt.IsTrue)
+	}
+	qt.Assert(t, q.TryPush(4), qt.IsFalse)
+	qt.Assert(t, q.Len(), qt.Equals, 4)
+	qt.Assert(t, q.TryPop().Value(), qt.Equals, 0)
+	qt.Assert(t, q.TryPush(4), qt.IsTrue)
+	qt.Assert(t, collector.Collect(q.Drain(), collector.ToSlice[int]()), qt.DeepEquals, []int{1, 2, 3, 4})
+	qt.Assert(t, q.Len(), qt.Equals, 0)
+}
+
+func TestMPMCQueue_Concurrent(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 1000
+	q := concurrent.NewMPMCQueue[int](64)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				for !q.TryPush(p*perProducer + i) {
+					runtime.Gosched()
+				}
+			}
+		}(p)
+	}
+	seen := make([][]int, consumers)
+	var cwg sync.WaitGroup
+	var mu sync.Mutex
+	total := 0
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func(c int) {
+			defer cwg.Done()
+			for {
+				mu.Lock()
+				if total == producers*perProducer {
+					mu.Unlock()
+					return
+				}
+				mu.Unlock()
+				if e := q.TryPop(); e.IsSome() {
+					seen[c] = append(seen[c], e.Value())
+					mu.Lock()
+					total++
+					mu.Unlock()
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}(c)
+	}
+	wg.Wait()
+	cwg.Wait()
+
+	count := make([]int, producers*perProducer)
+	for _, s := range seen {
+		last := make(map[int]int)
+		for _, e := range s {
+			count[e]++
+			// elements of the same producer are popped in order by a consumer.
+			p := e / perProducer
+			if prev, ok := last[p]; ok {
+				qt.Assert(t, e > prev, qt.IsTrue)
+			}
+			last[p] = e
+		}
+	}
+	for _, c := range count {
+		qt.Assert(t, c, qt.Equals, 1)
+	}
+}
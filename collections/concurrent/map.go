@@ -0,0 +1,200 @@
+package concurrent
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/go-board/std/hash"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/optional"
+)
+
+type shard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+	_  cacheLinePad
+}
+
+// ConcurrentMap is a hash map split into shards guarded by their own locks,
+// writes to keys on different shards don't contend with each other.
+type ConcurrentMap[K comparable, V any] struct {
+	hasher func(K) uint64
+	mask   uint64
+	shards []shard[K, V]
+}
+
+// NewConcurrentMap creates a [ConcurrentMap] which picks shards by hasher,
+// with a shard count scaled to GOMAXPROCS.
+func NewConcurrentMap[K comparable, V any](hasher func(K) uint64) *ConcurrentMap[K, V] {
+	return NewConcurrentMapWithShards[K, V](runtime.GOMAXPROCS(0)*4, hasher)
+}
+
+// NewConcurrentMapWithShards creates a [ConcurrentMap] with n shards rounded up to a power of two.
+func NewConcurrentMapWithShards[K comparable, V any](n int, hasher func(K) uint64) *ConcurrentMap[K, V] {
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	m := &ConcurrentMap[K, V]{hasher: hasher, mask: uint64(size - 1), shards: make([]shard[K, V], size)}
+	for i := range m.shards {
+		m.shards[i].m = make(map[K]V)
+	}
+	return m
+}
+
+// NewHashableMap creates a [ConcurrentMap] whose keys pick shards by [hash.Hash].
+func NewHashableMap[K interface {
+	comparable
+	hash.Hashable
+}, V any]() *ConcurrentMap[K, V] {
+	return NewConcurrentMap[K, V](hash.Hash[K])
+}
+
+func (self *ConcurrentMap[K, V]) shard(key K) *shard[K, V] {
+	h := self.hasher(key)
+	// fold high bits in, as the low bits of weak hash functions tend to collide.
+	h ^= h >> 33
+	return &self.shards[h&self.mask]
+}
+
+// Get returns the value for the given key.
+func (self *ConcurrentMap[K, V]) Get(key K) optional.Optional[V] {
+	s := self.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.m[key]
+	return optional.FromPair(v, ok)
+}
+
+// ContainsKey returns true if the map contains the given key.
+func (self *ConcurrentMap[K, V]) ContainsKey(key K) bool {
+	return self.Get(key).IsSome()
+}
+
+// Insert inserts a k-v pair, returns the previous value if the key exists.
+func (self *ConcurrentMap[K, V]) Insert(key K, value V) optional.Optional[V] {
+	s := self.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.m[key]
+	s.m[key] = value
+	return optional.FromPair(prev, ok)
+}
+
+// InsertKV inserts all k-v pairs in [iter.Seq2].
+func (self *ConcurrentMap[K, V]) InsertKV(it iter.Seq2[K, V]) {
+	iter.ForEachKV(it, func(k K, v V) { self.Insert(k, v) })
+}
+
+// Remove removes the given key, returns its value if it exists.
+func (self *ConcurrentMap[K, V]) Remove(key K) optional.Optional[V] {
+	s := self.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev, ok := s.m[key]
+	delete(s.m, key)
+	return optional.FromPair(prev, ok)
+}
+
+// Compute atomically replaces the value of key with the result of f.
+//
+// f receives the current value and whether key is present, it returns the new value
+// and whether to keep it, key is deleted if not kept. Compute returns what f returned.
+// f runs while holding the lock of the shard, it must not access the map.
+func (self *ConcurrentMap[K, V]) Compute(key K, f func(old V, loaded bool) (V, bool)) (V, bool) {
+	s := self.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, loaded := s.m[key]
+	v, keep := f(old, loaded)
+	if keep {
+		s.m[key] = v
+	} else if loaded {
+		delete(s.m, key)
+	}
+	return v, keep
+}
+
+// ComputeIfAbsent returns the value of key, stores f(key) first if key is absent.
+//
+// f runs at most once per absent key, while holding the lock of the shard,
+// it must not access the map.
+func (self *ConcurrentMap[K, V]) ComputeIfAbsent(key K, f func(K) V) V {
+	s := self.shard(key)
+	s.mu.RLock()
+	v, ok := s.m[key]
+	s.mu.RUnlock()
+	if ok {
+		return v
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.m[key]; ok {
+		return v
+	}
+	v = f(key)
+	s.m[key] = v
+	return v
+}
+
+// Len returns the number of entries.
+//
+// Shards are counted one by one, so under concurrent modification
+// it's an estimate rather than a snapshot.
+func (self *ConcurrentMap[K, V]) Len() int {
+	n := 0
+	for i := range self.shards {
+		s := &self.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Clear removes all entries.
+func (self *ConcurrentMap[K, V]) Clear() {
+	for i := range self.shards {
+		s := &self.shards[i]
+		s.mu.Lock()
+		s.m = make(map[K]V)
+		s.mu.Unlock()
+	}
+}
+
+// Iter returns an iterator over the k-v pairs in the map.
+//
+// Each shard is copied under its lock before being yielded, so iteration never blocks writers
+// for long and the map may be modified while iterating. The copy of each shard is consistent,
+// but shards are copied at different moments.
+func (self *ConcurrentMap[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var keys []K
+		var values []V
+		for i := range self.shards {
+			s := &self.shards[i]
+			keys, values = keys[:0], values[:0]
+			s.mu.RLock()
+			for k, v := range s.m {
+				keys = append(keys, k)
+				values = append(values, v)
+			}
+			s.mu.RUnlock()
+			for j := range keys {
+				if !yield(keys[j], values[j]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys in the map, see [ConcurrentMap.Iter].
+func (self *ConcurrentMap[K, V]) Keys() iter.Seq[K] {
+	return iter.Keys(self.Iter())
+}
+
+// Values returns an iterator over the values in the map, see [ConcurrentMap.Iter].
+func (self *ConcurrentMap[K, V]) Values() iter.Seq[V] {
+	return iter.Values(self.Iter())
+}
@@ -0,0 +1,153 @@
+package concurrent_test
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/collections/concurrent"
+	"github.com/go-board/std/hash"
+	"github.com/go-board/std/iter/collector"
+	xsync "github.com/go-board/std/sync"
+)
+
+func intHash(x int) uint64 { return hash.Int64(int64(x)) }
+
+type point struct{ x, y int }
+
+func (p point) Hash(state hash.Hasher) {
+	state.WriteInt(p.x)
+	state.WriteInt(p.y)
+}
+
+func TestConcurrentMap(t *testing.T) {
+	m := concurrent.NewConcurrentMap[string, int](hash.BytesLike[string])
+	qt.Assert(t, m.Get("a").IsNone(), qt.IsTrue)
+	qt.Assert(t, m.Insert("a", 1).IsNone(), qt.IsTrue)
+	qt.Assert(t, m.Insert("a", 2).Value(), qt.Equals, 1)
+	qt.Assert(t, m.Get("a").Value(), qt.Equals, 2)
+	qt.Assert(t, m.ContainsKey("a"), qt.IsTrue)
+	qt.Assert(t, m.Remove("a").Value(), qt.Equals, 2)
+	qt.Assert(t, m.Remove("a").IsNone(), qt.IsTrue)
+	qt.Assert(t, m.Len(), qt.Equals, 0)
+
+	qt.Assert(t, m.ComputeIfAbsent("b", func(string) int { return 3 }), qt.Equals, 3)
+	qt.Assert(t, m.ComputeIfAbsent("b", func(string) int { return 4 }), qt.Equals, 3)
+	v, ok := m.Compute("b", func(old int, loaded bool) (int, bool) { return old * 10, loaded })
+	qt.Assert(t, ok, qt.IsTrue)
+	qt.Assert(t, v, qt.Equals, 30)
+	_, ok = m.Compute("b", func(int, bool) (int, bool) { return 0, false })
+	qt.Assert(t, ok, qt.IsFalse)
+	qt.Assert(t, m.ContainsKey("b"), qt.IsFalse)
+}
+
+func TestConcurrentMap_Hashable(t *testing.T) {
+	m := concurrent.NewHashableMap[point, string]()
+	m.Insert(point{1, 2}, "a")
+	m.Insert(point{2, 1}, "b")
+	qt.Assert(t, m.Get(point{1, 2}).Value(), qt.Equals, "a")
+	qt.Assert(t, m.Len(), qt.Equals, 2)
+}
+
+func TestConcurrentMap_Iter(t *testing.T) {
+	m := concurrent.NewConcurrentMapWithShards[int, int](3, intHash)
+	for i := 0; i < 100; i++ {
+		m.Insert(i, i*i)
+	}
+	keys := collector.Collect(m.Keys(), collector.ToSlice[int]())
+	sort.Ints(keys)
+	qt.Assert(t, len(keys), qt.Equals, 100)
+	for i, k := range keys {
+		qt.Assert(t, k, qt.Equals, i)
+	}
+	sum := 0
+	m.Iter()(func(k, v int) bool {
+		qt.Assert(t, v, qt.Equals, k*k)
+		// writing while iterating doesn't deadlock.
+		m.Insert(k, v)
+		sum++
+		return sum < 10
+	})
+	qt.Assert(t, sum, qt.Equals, 10)
+	m.Clear()
+	qt.Assert(t, m.Len(), qt.Equals, 0)
+}
+
+func TestConcurrentMap_Concurrent(t *testing.T) {
+	m := concurrent.NewConcurrentMap[int, int](intHash)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				m.Compute(i%10, func(old int, _ bool) (int, bool) { return old + 1, true })
+			}
+		}()
+	}
+	wg.Wait()
+	qt.Assert(t, m.Len(), qt.Equals, 10)
+	for i := 0; i < 10; i++ {
+		qt.Assert(t, m.Get(i).Value(), qt.Equals, 160)
+	}
+}
+
+const benchKeys = 1 << 12
+
+func BenchmarkConcurrentMap_Insert(b *testing.B) {
+	m := concurrent.NewConcurrentMap[int, int](intHash)
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			m.Insert(i%benchKeys, i)
+		}
+	})
+}
+
+func BenchmarkSyncMap_Insert(b *testing.B) {
+	var m xsync.Map[int, int]
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			m.Insert(i%benchKeys, i)
+		}
+	})
+}
+
+func BenchmarkConcurrentMap_Mixed(b *testing.B) {
+	m := concurrent.NewConcurrentMap[string, int](hash.BytesLike[string])
+	keys := benchStringKeys()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			k := keys[i%benchKeys]
+			if i%4 == 0 {
+				m.Insert(k, i)
+			} else {
+				m.Get(k)
+			}
+		}
+	})
+}
+
+func BenchmarkSyncMap_Mixed(b *testing.B) {
+	var m xsync.Map[string, int]
+	keys := benchStringKeys()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			k := keys[i%benchKeys]
+			if i%4 == 0 {
+				m.Insert(k, i)
+			} else {
+				m.Get(k)
+			}
+		}
+	})
+}
+
+func benchStringKeys() []string {
+	keys := make([]string, benchKeys)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	return keys
+}
@@ -0,0 +1,111 @@
+package concurrent
+
+import (
+	"sync/atomic"
+
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/optional"
+)
+
+// cacheLinePad keeps hot counters on separate cache lines to avoid false sharing.
+type cacheLinePad struct{ _ [64]byte }
+
+type slot[E any] struct {
+	// seq tells the state of slot, equals to the position when it's ready to be written,
+	// and position+1 when it's ready to be read.
+	seq  atomic.Uint64
+	elem E
+}
+
+// MPMCQueue is a bounded lock-free FIFO queue for multiple producers and multiple consumers.
+//
+// It never blocks, use [BlockingQueue] if producers or consumers need to wait.
+type MPMCQueue[E any] struct {
+	_     cacheLinePad
+	tail  atomic.Uint64
+	_     cacheLinePad
+	head  atomic.Uint64
+	_     cacheLinePad
+	mask  uint64
+	slots []slot[E]
+}
+
+// NewMPMCQueue creates a [MPMCQueue], its capacity is n rounded up to a power of two.
+//
+// If n less than 2, it's set to 2.
+func NewMPMCQueue[E any](n int) *MPMCQueue[E] {
+	size := uint64(2)
+	for size < uint64(n) {
+		size <<= 1
+	}
+	q := &MPMCQueue[E]{mask: size - 1, slots: make([]slot[E], size)}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// TryPush appends an element, returns false if [MPMCQueue] is full.
+func (q *MPMCQueue[E]) TryPush(e E) bool {
+	for {
+		pos := q.tail.Load()
+		s := &q.slots[pos&q.mask]
+		seq := s.seq.Load()
+		switch {
+		case seq == pos:
+			if q.tail.CompareAndSwap(pos, pos+1) {
+				s.elem = e
+				s.seq.Store(pos + 1)
+				return true
+			}
+		case seq < pos:
+			// the slot still holds an element of the previous lap.
+			return false
+		}
+	}
+}
+
+// TryPop removes the first element, returns None if [MPMCQueue] is empty.
+func (q *MPMCQueue[E]) TryPop() optional.Optional[E] {
+	for {
+		pos := q.head.Load()
+		s := &q.slots[pos&q.mask]
+		seq := s.seq.Load()
+		switch {
+		case seq == pos+1:
+			if q.head.CompareAndSwap(pos, pos+1) {
+				var zero E
+				e := s.elem
+				s.elem = zero
+				s.seq.Store(pos + q.mask + 1)
+				return optional.Some(e)
+			}
+		case seq < pos+1:
+			// the slot hasn't been written in this lap.
+			return optional.None[E]()
+		}
+	}
+}
+
+// Len returns the number of elements in [MPMCQueue].
+//
+// It's a snapshot and may be stale as soon as it returns under concurrent use.
+func (q *MPMCQueue[E]) Len() int {
+	head := q.head.Load()
+	tail := q.tail.Load()
+	if tail < head {
+		return 0
+	}
+	if n := tail - head; n <= q.mask {
+		return int(n)
+	}
+	return len(q.slots)
+}
+
+// Cap returns the max number of elements [MPMCQueue] can hold.
+func (q *MPMCQueue[E]) Cap() int { return len(q.slots) }
+
+// Drain creates an [iter.Seq] which pops elements until [MPMCQueue] is empty.
+func (q *MPMCQueue[E]) Drain() iter.Seq[E] {
+	return drain(q.TryPop)
+}
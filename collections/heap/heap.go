@@ -0,0 +1,252 @@
+package heap
+
+import (
+	"github.com/go-board/std/cmp"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/optional"
+)
+
+// Handle refers to an element pushed into a [Heap],
+// it can be used to update or remove the element later.
+type Handle[E any] struct {
+	elem  E
+	index int
+	heap  *Heap[E]
+}
+
+// Value returns the element referred by [Handle].
+func (h *Handle[E]) Value() E { return h.elem }
+
+// Valid tests whether the element referred by [Handle] is still in a heap.
+func (h *Handle[E]) Valid() bool { return h.heap != nil }
+
+// Heap is a d-ary min-heap, the least element with respect to the
+// comparison function is on the top.
+//
+// For a max-heap, use a reversed comparison function.
+type Heap[E any] struct {
+	cmp   func(E, E) int
+	arity int
+	items []*Handle[E]
+}
+
+// New creates an empty binary [Heap] using the given comparison function.
+func New[E any](cmp func(E, E) int) *Heap[E] {
+	return NewDary(2, cmp)
+}
+
+// NewOrdered creates an empty binary [Heap] from Ordered type.
+func NewOrdered[E cmp.Ordered]() *Heap[E] {
+	return New(cmp.Compare[E])
+}
+
+// NewDary creates an empty d-ary [Heap] using the given comparison function.
+//
+// A wider heap is shallower, which makes push and update cheaper and pop more expensive.
+// If d less than 2, it's set to 2.
+func NewDary[E any](d int, cmp func(E, E) int) *Heap[E] {
+	if d < 2 {
+		d = 2
+	}
+	return &Heap[E]{cmp: cmp, arity: d}
+}
+
+// FromSlice creates a binary [Heap] from variadic elements in O(n).
+func FromSlice[E any](cmp func(E, E) int, elems ...E) *Heap[E] {
+	h := New(cmp)
+	for _, e := range elems {
+		h.items = append(h.items, &Handle[E]{elem: e, index: len(h.items), heap: h})
+	}
+	h.heapify()
+	return h
+}
+
+// FromIter creates a binary [Heap] from [iter.Seq] in O(n).
+func FromIter[E any](cmp func(E, E) int, it iter.Seq[E]) *Heap[E] {
+	h := New(cmp)
+	iter.ForEach(it, func(e E) { h.items = append(h.items, &Handle[E]{elem: e, index: len(h.items), heap: h}) })
+	h.heapify()
+	return h
+}
+
+func (self *Heap[E]) less(i, j int) bool {
+	return self.cmp(self.items[i].elem, self.items[j].elem) < 0
+}
+
+func (self *Heap[E]) swap(i, j int) {
+	self.items[i], self.items[j] = self.items[j], self.items[i]
+	self.items[i].index = i
+	self.items[j].index = j
+}
+
+func (self *Heap[E]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / self.arity
+		if !self.less(i, parent) {
+			break
+		}
+		self.swap(i, parent)
+		i = parent
+	}
+}
+
+func (self *Heap[E]) down(i int) bool {
+	start := i
+	n := len(self.items)
+	for {
+		first := i*self.arity + 1
+		if first >= n || first < 0 {
+			break
+		}
+		least := first
+		for c := first + 1; c < first+self.arity && c < n; c++ {
+			if self.less(c, least) {
+				least = c
+			}
+		}
+		if !self.less(least, i) {
+			break
+		}
+		self.swap(i, least)
+		i = least
+	}
+	return i > start
+}
+
+func (self *Heap[E]) fix(i int) {
+	if !self.down(i) {
+		self.up(i)
+	}
+}
+
+func (self *Heap[E]) heapify() {
+	n := len(self.items)
+	for i := (n - 2) / self.arity; i >= 0 && n > 1; i-- {
+		self.down(i)
+	}
+}
+
+// Len returns the number of elements in [Heap].
+func (self *Heap[E]) Len() int { return len(self.items) }
+
+// IsEmpty tests whether [Heap] is empty.
+func (self *Heap[E]) IsEmpty() bool { return self.Len() == 0 }
+
+// Push pushes an element into [Heap], and returns a [Handle] of it.
+func (self *Heap[E]) Push(elem E) *Handle[E] {
+	h := &Handle[E]{elem: elem, index: len(self.items), heap: self}
+	self.items = append(self.items, h)
+	self.up(h.index)
+	return h
+}
+
+// PushIter pushes all elements in [iter.Seq] into [Heap].
+func (self *Heap[E]) PushIter(it iter.Seq[E]) {
+	iter.ForEach(it, func(e E) { self.Push(e) })
+}
+
+// Peek returns the top element without removing it, or None if [Heap] is empty.
+func (self *Heap[E]) Peek() optional.Optional[E] {
+	if len(self.items) == 0 {
+		return optional.None[E]()
+	}
+	return optional.Some(self.items[0].elem)
+}
+
+// Pop removes and returns the top element, or None if [Heap] is empty.
+func (self *Heap[E]) Pop() optional.Optional[E] {
+	if len(self.items) == 0 {
+		return optional.None[E]()
+	}
+	return optional.Some(self.remove(0))
+}
+
+func (self *Heap[E]) remove(i int) E {
+	n := len(self.items) - 1
+	if i != n {
+		self.swap(i, n)
+	}
+	h := self.items[n]
+	self.items[n] = nil
+	self.items = self.items[:n]
+	if i != n {
+		self.fix(i)
+	}
+	h.heap, h.index = nil, -1
+	return h.elem
+}
+
+func (self *Heap[E]) owns(h *Handle[E]) bool {
+	return h != nil && h.heap == self
+}
+
+// Update replaces the element referred by [Handle] and restores the heap order,
+// it works for both decrease-key and increase-key.
+//
+// Returns false if [Handle] doesn't belong to this [Heap].
+func (self *Heap[E]) Update(h *Handle[E], elem E) bool {
+	if !self.owns(h) {
+		return false
+	}
+	h.elem = elem
+	self.fix(h.index)
+	return true
+}
+
+// Remove removes the element referred by [Handle].
+//
+// Returns false if [Handle] doesn't belong to this [Heap].
+func (self *Heap[E]) Remove(h *Handle[E]) bool {
+	if !self.owns(h) {
+		return false
+	}
+	self.remove(h.index)
+	return true
+}
+
+// Merge moves all elements of o into [Heap] in O(n+m), o becomes empty.
+//
+// Handles of elements from o stay valid and refer to this [Heap] afterwards.
+func (self *Heap[E]) Merge(o *Heap[E]) {
+	if o == self {
+		return
+	}
+	for _, h := range o.items {
+		h.heap, h.index = self, len(self.items)
+		self.items = append(self.items, h)
+	}
+	o.items = nil
+	self.heapify()
+}
+
+// Clear removes all elements.
+func (self *Heap[E]) Clear() {
+	for _, h := range self.items {
+		h.heap, h.index = nil, -1
+	}
+	self.items = nil
+}
+
+// Iter returns an [iter.Seq] over all elements in unspecified order, without removing them.
+func (self *Heap[E]) Iter() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for _, h := range self.items {
+			if !yield(h.elem) {
+				break
+			}
+		}
+	}
+}
+
+// Drain returns an [iter.Seq] which pops elements in heap order.
+//
+// Elements not yielded when iteration stops early stay in [Heap].
+func (self *Heap[E]) Drain() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for len(self.items) > 0 {
+			if !yield(self.remove(0)) {
+				break
+			}
+		}
+	}
+}
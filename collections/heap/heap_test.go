@@ -0,0 +1,130 @@
+package heap_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/cmp"
+	"github.com/go-board/std/collections/heap"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/iter/collector"
+	"github.com/go-board/std/iter/source"
+)
+
+func drain[E any](h *heap.Heap[E]) []E {
+	return collector.Collect(h.Drain(), collector.ToSlice[E]())
+}
+
+func TestHeap_PushPop(t *testing.T) {
+	for _, d := range []int{0, 2, 3, 4, 8} {
+		h := heap.NewDary(d, cmp.Compare[int])
+		qt.Assert(t, h.Pop().IsNone(), qt.IsTrue)
+		qt.Assert(t, h.Peek().IsNone(), qt.IsTrue)
+		elems := rand.Perm(100)
+		for _, e := range elems {
+			h.Push(e)
+		}
+		qt.Assert(t, h.Len(), qt.Equals, 100)
+		qt.Assert(t, h.Peek().Value(), qt.Equals, 0)
+		sort.Ints(elems)
+		qt.Assert(t, drain(h), qt.DeepEquals, elems)
+		qt.Assert(t, h.IsEmpty(), qt.IsTrue)
+	}
+}
+
+func TestHeap_Max(t *testing.T) {
+	h := heap.FromSlice(func(a, b int) int { return cmp.Compare(b, a) }, 3, 1, 4, 1, 5, 9, 2)
+	qt.Assert(t, drain(h), qt.DeepEquals, []int{9, 5, 4, 3, 2, 1, 1})
+}
+
+func TestFromIter(t *testing.T) {
+	h := heap.FromIter(cmp.Compare[int], source.Variadic(5, 3, 8, 1))
+	qt.Assert(t, h.Len(), qt.Equals, 4)
+	qt.Assert(t, drain(h), qt.DeepEquals, []int{1, 3, 5, 8})
+}
+
+func TestHeap_Handle(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	h := heap.New(func(a, b task) int { return cmp.Compare(a.priority, b.priority) })
+	a := h.Push(task{"a", 5})
+	b := h.Push(task{"b", 3})
+	c := h.Push(task{"c", 7})
+	h.Push(task{"d", 4})
+
+	qt.Assert(t, h.Update(c, task{"c", 1}), qt.IsTrue)
+	qt.Assert(t, h.Peek().Value().name, qt.Equals, "c")
+	qt.Assert(t, h.Update(c, task{"c", 10}), qt.IsTrue)
+	qt.Assert(t, h.Remove(b), qt.IsTrue)
+	qt.Assert(t, b.Valid(), qt.IsFalse)
+	qt.Assert(t, h.Remove(b), qt.IsFalse)
+	qt.Assert(t, a.Value(), qt.Equals, task{"a", 5})
+
+	names := iter.Map(h.Drain(), func(t task) string { return t.name })
+	qt.Assert(t, collector.Collect(names, collector.ToSlice[string]()), qt.DeepEquals, []string{"d", "a", "c"})
+	qt.Assert(t, a.Valid(), qt.IsFalse)
+	qt.Assert(t, h.Update(a, task{"a", 0}), qt.IsFalse)
+}
+
+func TestHeap_Merge(t *testing.T) {
+	x := heap.FromSlice(cmp.Compare[int], 5, 1, 9)
+	y := heap.NewDary(4, cmp.Compare[int])
+	y.PushIter(source.Variadic(4, 8))
+	h := y.Push(6)
+	x.Merge(y)
+	qt.Assert(t, y.Len(), qt.Equals, 0)
+	qt.Assert(t, x.Len(), qt.Equals, 6)
+	qt.Assert(t, x.Update(h, 0), qt.IsTrue)
+	qt.Assert(t, y.Remove(h), qt.IsFalse)
+	qt.Assert(t, drain(x), qt.DeepEquals, []int{0, 1, 4, 5, 8, 9})
+}
+
+func TestHeap_Drain(t *testing.T) {
+	h := heap.FromSlice(cmp.Compare[int], 4, 2, 3, 1)
+	first := collector.Collect(iter.Take(h.Drain(), 2), collector.ToSlice[int]())
+	qt.Assert(t, first, qt.DeepEquals, []int{1, 2})
+	qt.Assert(t, h.Len() >= 1, qt.IsTrue)
+	qt.Assert(t, h.Peek().IsSome(), qt.IsTrue)
+
+	h.Clear()
+	qt.Assert(t, h.IsEmpty(), qt.IsTrue)
+	qt.Assert(t, iter.Size(h.Iter()), qt.Equals, 0)
+}
+
+func TestHeap_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := heap.NewDary(3, cmp.Compare[int])
+	handles := map[int]*heap.Handle[int]{}
+	live := map[int]int{}
+	for i := 0; i < 1000; i++ {
+		switch r.Intn(3) {
+		case 0:
+			handles[i] = h.Push(r.Intn(1000))
+			live[i] = handles[i].Value()
+		case 1:
+			for k, hd := range handles {
+				v := r.Intn(1000)
+				h.Update(hd, v)
+				live[k] = v
+				break
+			}
+		case 2:
+			for k, hd := range handles {
+				h.Remove(hd)
+				delete(handles, k)
+				delete(live, k)
+				break
+			}
+		}
+	}
+	want := make([]int, 0, len(live))
+	for _, v := range live {
+		want = append(want, v)
+	}
+	sort.Ints(want)
+	qt.Assert(t, drain(h), qt.DeepEquals, want)
+}
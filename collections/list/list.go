@@ -0,0 +1,291 @@
+// Package list implements a generic doubly linked list.
+package list
+
+import (
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/optional"
+)
+
+// Node is an element of [List], it also serves as a cursor to walk and edit the list.
+type Node[E any] struct {
+	// Value is the element stored in this node.
+	Value E
+
+	prev, next *Node[E]
+	list       *List[E]
+}
+
+// Next returns the next node, or nil if it's the last one or has been removed.
+func (self *Node[E]) Next() *Node[E] {
+	if self.list == nil || self.next == &self.list.root {
+		return nil
+	}
+	return self.next
+}
+
+// Prev returns the previous node, or nil if it's the first one or has been removed.
+func (self *Node[E]) Prev() *Node[E] {
+	if self.list == nil || self.prev == &self.list.root {
+		return nil
+	}
+	return self.prev
+}
+
+// List is a doubly linked list.
+//
+// The zero value is an empty [List] ready to use.
+type List[E any] struct {
+	// root is a sentinel, root.next is the front and root.prev is the back.
+	root Node[E]
+	len  int
+}
+
+// New creates an empty [List].
+func New[E any]() *List[E] {
+	return new(List[E]).lazyInit()
+}
+
+// FromSlice creates a [List] from variadic elements.
+func FromSlice[E any](elems ...E) *List[E] {
+	l := New[E]()
+	for _, e := range elems {
+		l.PushBack(e)
+	}
+	return l
+}
+
+// FromIter creates a [List] from [iter.Seq].
+func FromIter[E any](it iter.Seq[E]) *List[E] {
+	l := New[E]()
+	l.PushBackIter(it)
+	return l
+}
+
+func (self *List[E]) lazyInit() *List[E] {
+	if self.root.next == nil {
+		self.root.next = &self.root
+		self.root.prev = &self.root
+	}
+	return self
+}
+
+// link puts n after at.
+func (self *List[E]) link(n, at *Node[E]) *Node[E] {
+	n.prev = at
+	n.next = at.next
+	n.prev.next = n
+	n.next.prev = n
+	n.list = self
+	self.len++
+	return n
+}
+
+func (self *List[E]) unlink(n *Node[E]) {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.prev, n.next, n.list = nil, nil, nil
+	self.len--
+}
+
+// move puts n after at.
+func (self *List[E]) move(n, at *Node[E]) {
+	if n == at || n == at.next {
+		return
+	}
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.prev = at
+	n.next = at.next
+	n.prev.next = n
+	n.next.prev = n
+}
+
+func (self *List[E]) owns(n *Node[E]) bool {
+	return n != nil && n.list == self
+}
+
+// Len returns the number of elements in [List].
+func (self *List[E]) Len() int { return self.len }
+
+// IsEmpty tests whether [List] is empty.
+func (self *List[E]) IsEmpty() bool { return self.len == 0 }
+
+// Front returns the first node, or nil if [List] is empty.
+func (self *List[E]) Front() *Node[E] {
+	if self.len == 0 {
+		return nil
+	}
+	return self.root.next
+}
+
+// Back returns the last node, or nil if [List] is empty.
+func (self *List[E]) Back() *Node[E] {
+	if self.len == 0 {
+		return nil
+	}
+	return self.root.prev
+}
+
+// PushFront prepends an element and returns its node.
+func (self *List[E]) PushFront(e E) *Node[E] {
+	self.lazyInit()
+	return self.link(&Node[E]{Value: e}, &self.root)
+}
+
+// PushBack appends an element and returns its node.
+func (self *List[E]) PushBack(e E) *Node[E] {
+	self.lazyInit()
+	return self.link(&Node[E]{Value: e}, self.root.prev)
+}
+
+// PushBackIter appends all elements in [iter.Seq].
+func (self *List[E]) PushBackIter(it iter.Seq[E]) {
+	iter.ForEach(it, func(e E) { self.PushBack(e) })
+}
+
+// InsertBefore inserts an element right before mark and returns its node.
+//
+// Returns nil if mark is not a node of [List].
+func (self *List[E]) InsertBefore(e E, mark *Node[E]) *Node[E] {
+	if !self.owns(mark) {
+		return nil
+	}
+	return self.link(&Node[E]{Value: e}, mark.prev)
+}
+
+// InsertAfter inserts an element right after mark and returns its node.
+//
+// Returns nil if mark is not a node of [List].
+func (self *List[E]) InsertAfter(e E, mark *Node[E]) *Node[E] {
+	if !self.owns(mark) {
+		return nil
+	}
+	return self.link(&Node[E]{Value: e}, mark)
+}
+
+// Remove removes the node from [List] and returns its element.
+//
+// Returns None if n is not a node of [List].
+func (self *List[E]) Remove(n *Node[E]) optional.Optional[E] {
+	if !self.owns(n) {
+		return optional.None[E]()
+	}
+	self.unlink(n)
+	return optional.Some(n.Value)
+}
+
+// PopFront removes and returns the first element, or None if [List] is empty.
+func (self *List[E]) PopFront() optional.Optional[E] {
+	return self.Remove(self.Front())
+}
+
+// PopBack removes and returns the last element, or None if [List] is empty.
+func (self *List[E]) PopBack() optional.Optional[E] {
+	return self.Remove(self.Back())
+}
+
+// MoveToFront moves n to the front of [List].
+func (self *List[E]) MoveToFront(n *Node[E]) {
+	if self.owns(n) {
+		self.move(n, &self.root)
+	}
+}
+
+// MoveToBack moves n to the back of [List].
+func (self *List[E]) MoveToBack(n *Node[E]) {
+	if self.owns(n) {
+		self.move(n, self.root.prev)
+	}
+}
+
+// MoveBefore moves n right before mark.
+func (self *List[E]) MoveBefore(n, mark *Node[E]) {
+	if self.owns(n) && self.owns(mark) && n != mark {
+		self.move(n, mark.prev)
+	}
+}
+
+// MoveAfter moves n right after mark.
+func (self *List[E]) MoveAfter(n, mark *Node[E]) {
+	if self.owns(n) && self.owns(mark) {
+		self.move(n, mark)
+	}
+}
+
+// Splice moves all nodes of other right before mark, or to the back if mark is nil,
+// other becomes empty.
+//
+// Nodes keep their identity, so existing cursors into other stay valid and refer to [List].
+// It takes O(len(other)) time to transfer ownership of nodes.
+func (self *List[E]) Splice(mark *Node[E], other *List[E]) {
+	if other == self || other.len == 0 || (mark != nil && !self.owns(mark)) {
+		return
+	}
+	self.lazyInit()
+	at := self.root.prev
+	if mark != nil {
+		at = mark.prev
+	}
+	first, last := other.root.next, other.root.prev
+	for n := first; n != &other.root; n = n.next {
+		n.list = self
+	}
+	first.prev = at
+	last.next = at.next
+	at.next.prev = last
+	at.next = first
+	self.len += other.len
+	other.root.next, other.root.prev, other.len = &other.root, &other.root, 0
+}
+
+// Clear removes all elements.
+func (self *List[E]) Clear() {
+	for n := self.Front(); n != nil; {
+		next := n.Next()
+		n.prev, n.next, n.list = nil, nil, nil
+		n = next
+	}
+	self.root.next, self.root.prev, self.len = &self.root, &self.root, 0
+}
+
+// Forward creates an [iter.Seq] in forward order.
+//
+// Removing the yielded node during iteration is not allowed.
+func (self *List[E]) Forward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for n := self.Front(); n != nil; n = n.Next() {
+			if !yield(n.Value) {
+				break
+			}
+		}
+	}
+}
+
+// Backward creates an [iter.Seq] in backward order.
+//
+// Removing the yielded node during iteration is not allowed.
+func (self *List[E]) Backward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for n := self.Back(); n != nil; n = n.Prev() {
+			if !yield(n.Value) {
+				break
+			}
+		}
+	}
+}
+
+// Nodes creates an [iter.Seq] of nodes in forward order.
+//
+// The yielded node may be removed or moved during iteration,
+// iteration continues with the node that followed it.
+func (self *List[E]) Nodes() iter.Seq[*Node[E]] {
+	return func(yield func(*Node[E]) bool) {
+		for n := self.Front(); n != nil; {
+			next := n.Next()
+			if !yield(n) {
+				break
+			}
+			n = next
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package list_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/collections/list"
+	"github.com/go-board/std/iter/collector"
+	"github.com/go-board/std/iter/source"
+)
+
+func forward[E any](l *list.List[E]) []E {
+	return collector.Collect(l.Forward(), collector.ToSlice[E]())
+}
+
+func TestList_Push(t *testing.T) {
+	var l list.List[int]
+	qt.Assert(t, l.Front(), qt.IsNil)
+	qt.Assert(t, l.PopFront().IsNone(), qt.IsTrue)
+	l.PushBack(2)
+	l.PushFront(1)
+	l.PushBackIter(source.Variadic(3, 4))
+	qt.Assert(t, l.Len(), qt.Equals, 4)
+	qt.Assert(t, forward(&l), qt.DeepEquals, []int{1, 2, 3, 4})
+	qt.Assert(t, collector.Collect(l.Backward(), collector.ToSlice[int]()), qt.DeepEquals, []int{4, 3, 2, 1})
+	qt.Assert(t, l.PopFront().Value(), qt.Equals, 1)
+	qt.Assert(t, l.PopBack().Value(), qt.Equals, 4)
+	qt.Assert(t, forward(&l), qt.DeepEquals, []int{2, 3})
+}
+
+func TestList_Cursor(t *testing.T) {
+	l := list.FromSlice(1, 3, 5)
+	n := l.Front().Next()
+	qt.Assert(t, n.Value, qt.Equals, 3)
+	l.InsertBefore(2, n)
+	l.InsertAfter(4, n)
+	qt.Assert(t, forward(l), qt.DeepEquals, []int{1, 2, 3, 4, 5})
+	qt.Assert(t, l.Back().Next(), qt.IsNil)
+	qt.Assert(t, l.Front().Prev(), qt.IsNil)
+
+	l.MoveToFront(n)
+	qt.Assert(t, forward(l), qt.DeepEquals, []int{3, 1, 2, 4, 5})
+	l.MoveToBack(n)
+	qt.Assert(t, forward(l), qt.DeepEquals, []int{1, 2, 4, 5, 3})
+	l.MoveBefore(n, l.Front())
+	qt.Assert(t, forward(l), qt.DeepEquals, []int{3, 1, 2, 4, 5})
+	l.MoveAfter(n, l.Back())
+	qt.Assert(t, forward(l), qt.DeepEquals, []int{1, 2, 4, 5, 3})
+
+	qt.Assert(t, l.Remove(n).Value(), qt.Equals, 3)
+	qt.Assert(t, l.Remove(n).IsNone(), qt.IsTrue)
+	qt.Assert(t, n.Next(), qt.IsNil)
+	qt.Assert(t, l.InsertAfter(6, n), qt.IsNil)
+	qt.Assert(t, list.New[int]().Remove(l.Front()).IsNone(), qt.IsTrue)
+	qt.Assert(t, forward(l), qt.DeepEquals, []int{1, 2, 4, 5})
+}
+
+func TestList_Splice(t *testing.T) {
+	l := list.FromSlice(1, 4)
+	o := list.FromSlice(2, 3)
+	n := o.Front()
+	l.Splice(l.Back(), o)
+	qt.Assert(t, forward(l), qt.DeepEquals, []int{1, 2, 3, 4})
+	qt.Assert(t, l.Len(), qt.Equals, 4)
+	qt.Assert(t, o.Len(), qt.Equals, 0)
+	qt.Assert(t, forward(o), qt.HasLen, 0)
+
+	l.MoveToBack(n)
+	qt.Assert(t, forward(l), qt.DeepEquals, []int{1, 3, 4, 2})
+	l.Splice(nil, list.FromSlice(5))
+	qt.Assert(t, forward(l), qt.DeepEquals, []int{1, 3, 4, 2, 5})
+	var empty list.List[int]
+	empty.Splice(nil, l)
+	qt.Assert(t, forward(&empty), qt.DeepEquals, []int{1, 3, 4, 2, 5})
+	qt.Assert(t, l.IsEmpty(), qt.IsTrue)
+}
+
+func TestList_Nodes(t *testing.T) {
+	l := list.FromIter(source.Range1(6))
+	l.Nodes()(func(n *list.Node[int]) bool {
+		if n.Value%2 == 1 {
+			l.Remove(n)
+		}
+		return true
+	})
+	qt.Assert(t, forward(l), qt.DeepEquals, []int{0, 2, 4})
+	l.Clear()
+	qt.Assert(t, l.Len(), qt.Equals, 0)
+	qt.Assert(t, l.Front(), qt.IsNil)
+}
@@ -0,0 +1,177 @@
+package ordered
+
+import (
+	"github.com/go-board/std/collections/list"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/optional"
+)
+
+// LinkedHashMap is a hash map which remembers the order of its entries,
+// either the order in which keys were inserted, or the order in which they were accessed.
+type LinkedHashMap[K comparable, V any] struct {
+	index       map[K]*list.Node[MapEntry[K, V]]
+	entries     *list.List[MapEntry[K, V]]
+	accessOrder bool
+}
+
+// NewLinkedHashMap creates a new LinkedHashMap ordered by insertion,
+// re-inserting an existing key doesn't change its position.
+func NewLinkedHashMap[K comparable, V any]() *LinkedHashMap[K, V] {
+	return &LinkedHashMap[K, V]{
+		index:   make(map[K]*list.Node[MapEntry[K, V]]),
+		entries: list.New[MapEntry[K, V]](),
+	}
+}
+
+// NewAccessOrderedLinkedHashMap creates a new LinkedHashMap ordered by access,
+// from least recently to most recently accessed.
+//
+// Both [LinkedHashMap.Insert] and [LinkedHashMap.Get] count as an access,
+// which makes it a building block of LRU caches.
+func NewAccessOrderedLinkedHashMap[K comparable, V any]() *LinkedHashMap[K, V] {
+	m := NewLinkedHashMap[K, V]()
+	m.accessOrder = true
+	return m
+}
+
+func (self *LinkedHashMap[K, V]) touch(n *list.Node[MapEntry[K, V]]) {
+	if self.accessOrder {
+		self.entries.MoveToBack(n)
+	}
+}
+
+// Insert inserts a new MapEntry, returns the previous value if the key exists.
+func (self *LinkedHashMap[K, V]) Insert(key K, value V) optional.Optional[V] {
+	if n, ok := self.index[key]; ok {
+		prev := n.Value.Value()
+		n.Value = MakeMapEntry(key, value)
+		self.touch(n)
+		return optional.Some(prev)
+	}
+	self.index[key] = self.entries.PushBack(MakeMapEntry(key, value))
+	return optional.None[V]()
+}
+
+// InsertIter inserts all entries in [iter.Seq].
+func (self *LinkedHashMap[K, V]) InsertIter(it iter.Seq[MapEntry[K, V]]) {
+	iter.ForEach(it, func(e MapEntry[K, V]) { self.Insert(e.Key(), e.Value()) })
+}
+
+// InsertKV inserts all k-v pairs in [iter.Seq2].
+func (self *LinkedHashMap[K, V]) InsertKV(it iter.Seq2[K, V]) {
+	iter.ForEachKV(it, func(k K, v V) { self.Insert(k, v) })
+}
+
+// Get returns the value for the given key.
+func (self *LinkedHashMap[K, V]) Get(key K) optional.Optional[V] {
+	return optional.Map(self.GetEntry(key), MapEntry[K, V].Value)
+}
+
+// GetDefault returns the value for the given key or the default value.
+func (self *LinkedHashMap[K, V]) GetDefault(key K, value V) V {
+	return self.Get(key).ValueOr(value)
+}
+
+// GetEntry returns the MapEntry for the given key.
+func (self *LinkedHashMap[K, V]) GetEntry(key K) optional.Optional[MapEntry[K, V]] {
+	n, ok := self.index[key]
+	if !ok {
+		return optional.None[MapEntry[K, V]]()
+	}
+	self.touch(n)
+	return optional.Some(n.Value)
+}
+
+// Peek returns the value for the given key, without counting as an access.
+func (self *LinkedHashMap[K, V]) Peek(key K) optional.Optional[V] {
+	n, ok := self.index[key]
+	if !ok {
+		return optional.None[V]()
+	}
+	return optional.Some(n.Value.Value())
+}
+
+// ContainsKey returns true if the LinkedHashMap contains the given key.
+func (self *LinkedHashMap[K, V]) ContainsKey(key K) bool {
+	_, ok := self.index[key]
+	return ok
+}
+
+// Remove removes the MapEntry for the given key.
+func (self *LinkedHashMap[K, V]) Remove(key K) {
+	if n, ok := self.index[key]; ok {
+		delete(self.index, key)
+		self.entries.Remove(n)
+	}
+}
+
+// RemoveIter remove all elements in [iter.Seq].
+func (self *LinkedHashMap[K, V]) RemoveIter(it iter.Seq[K]) {
+	iter.ForEach(it, self.Remove)
+}
+
+// First returns the first MapEntry, the eldest one.
+func (self *LinkedHashMap[K, V]) First() optional.Optional[MapEntry[K, V]] {
+	return self.nodeEntry(self.entries.Front())
+}
+
+// Last returns the last MapEntry, the newest one.
+func (self *LinkedHashMap[K, V]) Last() optional.Optional[MapEntry[K, V]] {
+	return self.nodeEntry(self.entries.Back())
+}
+
+func (self *LinkedHashMap[K, V]) nodeEntry(n *list.Node[MapEntry[K, V]]) optional.Optional[MapEntry[K, V]] {
+	if n == nil {
+		return optional.None[MapEntry[K, V]]()
+	}
+	return optional.Some(n.Value)
+}
+
+// PopFirst removes and returns the first MapEntry.
+func (self *LinkedHashMap[K, V]) PopFirst() optional.Optional[MapEntry[K, V]] {
+	return self.popEntry(self.entries.PopFront())
+}
+
+// PopLast removes and returns the last MapEntry.
+func (self *LinkedHashMap[K, V]) PopLast() optional.Optional[MapEntry[K, V]] {
+	return self.popEntry(self.entries.PopBack())
+}
+
+func (self *LinkedHashMap[K, V]) popEntry(e optional.Optional[MapEntry[K, V]]) optional.Optional[MapEntry[K, V]] {
+	if e.IsSome() {
+		delete(self.index, e.Value().Key())
+	}
+	return e
+}
+
+// Keys returns an iterator over the keys in the map.
+func (self *LinkedHashMap[K, V]) Keys() iter.Seq[K] {
+	return iter.Map(self.entries.Forward(), MapEntry[K, V].Key)
+}
+
+// Values returns an iterator over the values in the map.
+func (self *LinkedHashMap[K, V]) Values() iter.Seq[V] {
+	return iter.Map(self.entries.Forward(), MapEntry[K, V].Value)
+}
+
+// Iter returns an iterator over the k-v pairs in the map.
+func (self *LinkedHashMap[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		self.entries.Forward()(func(e MapEntry[K, V]) bool { return yield(e.Key(), e.Value()) })
+	}
+}
+
+// Entries returns an iterator over the entries in the map.
+func (self *LinkedHashMap[K, V]) Entries() iter.Seq[MapEntry[K, V]] { return self.entries.Forward() }
+
+// Backward returns an iterator over the entries in the map in reversed order.
+func (self *LinkedHashMap[K, V]) Backward() iter.Seq[MapEntry[K, V]] { return self.entries.Backward() }
+
+// Len returns the number of entries in the map.
+func (self *LinkedHashMap[K, V]) Len() int { return len(self.index) }
+
+// Clear removes all entries.
+func (self *LinkedHashMap[K, V]) Clear() {
+	self.index = make(map[K]*list.Node[MapEntry[K, V]])
+	self.entries.Clear()
+}
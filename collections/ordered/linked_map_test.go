@@ -0,0 +1,58 @@
+package ordered_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/collections/ordered"
+	"github.com/go-board/std/iter/collector"
+)
+
+func keys[K comparable, V any](m *ordered.LinkedHashMap[K, V]) []K {
+	return collector.Collect(m.Keys(), collector.ToSlice[K]())
+}
+
+func TestLinkedHashMap_InsertionOrder(t *testing.T) {
+	m := ordered.NewLinkedHashMap[string, int]()
+	qt.Assert(t, m.Insert("c", 1).IsNone(), qt.IsTrue)
+	m.Insert("a", 2)
+	m.Insert("b", 3)
+	qt.Assert(t, m.Insert("c", 4).Value(), qt.Equals, 1)
+	qt.Assert(t, m.Get("a").Value(), qt.Equals, 2)
+	qt.Assert(t, keys(m), qt.DeepEquals, []string{"c", "a", "b"})
+	qt.Assert(t, collector.Collect(m.Values(), collector.ToSlice[int]()), qt.DeepEquals, []int{4, 2, 3})
+	qt.Assert(t, m.Len(), qt.Equals, 3)
+	qt.Assert(t, m.GetDefault("x", 9), qt.Equals, 9)
+
+	m.Remove("a")
+	m.Remove("x")
+	qt.Assert(t, m.ContainsKey("a"), qt.IsFalse)
+	qt.Assert(t, keys(m), qt.DeepEquals, []string{"c", "b"})
+	qt.Assert(t, m.First().Value().Key(), qt.Equals, "c")
+	qt.Assert(t, m.Last().Value().Key(), qt.Equals, "b")
+	qt.Assert(t, m.PopFirst().Value().Value(), qt.Equals, 4)
+	qt.Assert(t, m.Len(), qt.Equals, 1)
+	qt.Assert(t, m.PopLast().Value().Key(), qt.Equals, "b")
+	qt.Assert(t, m.PopLast().IsNone(), qt.IsTrue)
+}
+
+func TestLinkedHashMap_AccessOrder(t *testing.T) {
+	m := ordered.NewAccessOrderedLinkedHashMap[int, string]()
+	m.Insert(1, "a")
+	m.Insert(2, "b")
+	m.Insert(3, "c")
+	m.Get(1)
+	qt.Assert(t, keys(m), qt.DeepEquals, []int{2, 3, 1})
+	m.Insert(2, "B")
+	qt.Assert(t, keys(m), qt.DeepEquals, []int{3, 1, 2})
+	qt.Assert(t, m.Peek(3).Value(), qt.Equals, "c")
+	qt.Assert(t, keys(m), qt.DeepEquals, []int{3, 1, 2})
+	qt.Assert(t, m.PopFirst().Value().Key(), qt.Equals, 3)
+
+	var got []int
+	m.Iter()(func(k int, v string) bool { got = append(got, k); return true })
+	qt.Assert(t, got, qt.DeepEquals, []int{1, 2})
+	m.Clear()
+	qt.Assert(t, m.Len(), qt.Equals, 0)
+	qt.Assert(t, keys(m), qt.HasLen, 0)
+}
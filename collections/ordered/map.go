@@ -63,6 +63,11 @@ func (self *Map[K, V]) InsertIter(it iter.Seq[MapEntry[K, V]]) {
 	iter.ForEach(it, self.insertEntry)
 }
 
+// InsertKV inserts all k-v pairs in [iter.Seq2].
+func (self *Map[K, V]) InsertKV(it iter.Seq2[K, V]) {
+	iter.ForEachKV(it, func(k K, v V) { self.Insert(k, v) })
+}
+
 func (self *Map[K, V]) insertEntry(entry MapEntry[K, V]) {
 	self.inner.Set(entry)
 }
@@ -170,6 +175,13 @@ func (self *Map[K, V]) ValuesMut() iter.Seq[*ValueItem[K, V]] {
 	}
 }
 
+// Iter returns an iterator over the k-v pairs in the map.
+func (self *Map[K, V]) Iter() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		self.inner.Scan(func(item MapEntry[K, V]) bool { return yield(item.Key(), item.Value()) })
+	}
+}
+
 // Entries returns an iterator over the keys in the map.
 func (self *Map[K, V]) Entries() iter.Seq[MapEntry[K, V]] { return self.inner.Scan }
 
@@ -0,0 +1,31 @@
+package ordered_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/collections/ordered"
+)
+
+func TestMap_Iter(t *testing.T) {
+	m := ordered.NewOrderedMap[int, string]()
+	m.Insert(3, "c")
+	m.Insert(1, "a")
+	m.Insert(2, "b")
+	var keys []int
+	var values []string
+	m.Iter()(func(k int, v string) bool {
+		keys = append(keys, k)
+		values = append(values, v)
+		return true
+	})
+	qt.Assert(t, keys, qt.DeepEquals, []int{1, 2, 3})
+	qt.Assert(t, values, qt.DeepEquals, []string{"a", "b", "c"})
+
+	keys = nil
+	m.Iter()(func(k int, _ string) bool {
+		keys = append(keys, k)
+		return k < 2
+	})
+	qt.Assert(t, keys, qt.DeepEquals, []int{1, 2})
+}
@@ -0,0 +1,251 @@
+package queue
+
+import (
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/optional"
+)
+
+const minDequeCap = 8
+
+// Deque is a double ended queue backed by a growable ring buffer.
+//
+// Pushing and popping at both ends are amortized O(1), indexed access is O(1).
+// The zero value is an empty [Deque] ready to use.
+type Deque[E any] struct {
+	buf  []E
+	head int
+	len  int
+}
+
+// NewDeque creates an empty [Deque].
+func NewDeque[E any]() *Deque[E] {
+	return &Deque[E]{}
+}
+
+// NewDequeWithCapacity creates an empty [Deque] with room for at least n elements.
+func NewDequeWithCapacity[E any](n int) *Deque[E] {
+	if n < 0 {
+		n = 0
+	}
+	return &Deque[E]{buf: make([]E, n)}
+}
+
+// DequeFromSlice creates a [Deque] from slice, the slice is owned by [Deque] afterwards.
+func DequeFromSlice[E any](elems ...E) *Deque[E] {
+	return &Deque[E]{buf: elems, len: len(elems)}
+}
+
+// DequeFromIter creates a [Deque] from [iter.Seq].
+func DequeFromIter[E any](it iter.Seq[E]) *Deque[E] {
+	d := new(Deque[E])
+	d.PushBackIter(it)
+	return d
+}
+
+// physical maps a logical index into an index of the underlying buffer.
+func (d *Deque[E]) physical(i int) int {
+	i += d.head
+	if i >= len(d.buf) {
+		i -= len(d.buf)
+	}
+	return i
+}
+
+// resize moves all elements into a new buffer of capacity n, starting at index 0.
+func (d *Deque[E]) resize(n int) {
+	buf := make([]E, n)
+	if d.head+d.len <= len(d.buf) {
+		copy(buf, d.buf[d.head:d.head+d.len])
+	} else {
+		k := copy(buf, d.buf[d.head:])
+		copy(buf[k:], d.buf[:d.len-k])
+	}
+	d.buf, d.head = buf, 0
+}
+
+func (d *Deque[E]) grow() {
+	if d.len < len(d.buf) {
+		return
+	}
+	n := len(d.buf) * 2
+	if n < minDequeCap {
+		n = minDequeCap
+	}
+	d.resize(n)
+}
+
+// Len returns the number of elements in [Deque].
+func (d *Deque[E]) Len() int { return d.len }
+
+// Cap returns the number of elements [Deque] can hold without reallocating.
+func (d *Deque[E]) Cap() int { return len(d.buf) }
+
+// IsEmpty tests whether [Deque] is empty.
+func (d *Deque[E]) IsEmpty() bool { return d.len == 0 }
+
+// PushFront prepends an element to [Deque].
+func (d *Deque[E]) PushFront(element E) {
+	d.grow()
+	d.head--
+	if d.head < 0 {
+		d.head += len(d.buf)
+	}
+	d.buf[d.head] = element
+	d.len++
+}
+
+// PushFrontIter prepends all elements of [iter.Seq] one by one,
+// so they end up in reversed order at the front.
+func (d *Deque[E]) PushFrontIter(it iter.Seq[E]) {
+	iter.ForEach(it, d.PushFront)
+}
+
+// PushBack appends an element to [Deque].
+func (d *Deque[E]) PushBack(element E) {
+	d.grow()
+	d.buf[d.physical(d.len)] = element
+	d.len++
+}
+
+// PushBackIter appends all elements of [iter.Seq].
+func (d *Deque[E]) PushBackIter(it iter.Seq[E]) {
+	iter.ForEach(it, d.PushBack)
+}
+
+// PopFront removes and returns the first element, or None if [Deque] is empty.
+func (d *Deque[E]) PopFront() optional.Optional[E] {
+	if d.len == 0 {
+		return optional.None[E]()
+	}
+	var zero E
+	front := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.physical(1)
+	d.len--
+	return optional.Some(front)
+}
+
+// PopBack removes and returns the last element, or None if [Deque] is empty.
+func (d *Deque[E]) PopBack() optional.Optional[E] {
+	if d.len == 0 {
+		return optional.None[E]()
+	}
+	var zero E
+	i := d.physical(d.len - 1)
+	back := d.buf[i]
+	d.buf[i] = zero
+	d.len--
+	return optional.Some(back)
+}
+
+// PeekFront returns the first element, or None if [Deque] is empty.
+func (d *Deque[E]) PeekFront() optional.Optional[E] {
+	return d.Get(0)
+}
+
+// PeekBack returns the last element, or None if [Deque] is empty.
+func (d *Deque[E]) PeekBack() optional.Optional[E] {
+	return d.Get(d.len - 1)
+}
+
+// Get returns the element at index i counted from the front,
+// or None if i is out of range.
+func (d *Deque[E]) Get(i int) optional.Optional[E] {
+	if i < 0 || i >= d.len {
+		return optional.None[E]()
+	}
+	return optional.Some(d.buf[d.physical(i)])
+}
+
+// Set replaces the element at index i counted from the front,
+// returns false if i is out of range.
+func (d *Deque[E]) Set(i int, element E) bool {
+	if i < 0 || i >= d.len {
+		return false
+	}
+	d.buf[d.physical(i)] = element
+	return true
+}
+
+// Rotate rotates [Deque] n steps, so that the element at index n becomes the first one.
+//
+// A negative n rotates towards the back. It takes O(min(n, len-n)) time.
+//
+// Example:
+//
+//	DequeFromSlice(1,2,3,4,5).Rotate(2) => 3,4,5,1,2
+//	DequeFromSlice(1,2,3,4,5).Rotate(-1) => 5,1,2,3,4
+func (d *Deque[E]) Rotate(n int) {
+	if d.len == 0 {
+		return
+	}
+	n %= d.len
+	if n < 0 {
+		n += d.len
+	}
+	if n == 0 {
+		return
+	}
+	if d.len == len(d.buf) {
+		d.head = d.physical(n)
+		return
+	}
+	if n <= d.len/2 {
+		for ; n > 0; n-- {
+			d.PushBack(d.PopFront().Value())
+		}
+	} else {
+		for n = d.len - n; n > 0; n-- {
+			d.PushFront(d.PopBack().Value())
+		}
+	}
+}
+
+// Truncate keeps the first n elements and removes the rest.
+//
+// If n is greater than or equal to length, it does nothing.
+func (d *Deque[E]) Truncate(n int) {
+	if n < 0 {
+		n = 0
+	}
+	var zero E
+	for ; d.len > n; d.len-- {
+		d.buf[d.physical(d.len-1)] = zero
+	}
+}
+
+// Clear removes all elements, the capacity is kept.
+func (d *Deque[E]) Clear() {
+	d.Truncate(0)
+	d.head = 0
+}
+
+// ShrinkToFit reallocates the underlying buffer to hold exactly the current elements.
+func (d *Deque[E]) ShrinkToFit() {
+	if d.len == len(d.buf) {
+		return
+	}
+	d.resize(d.len)
+}
+
+// Forward creates an [iter.Seq] in forward order.
+func (d *Deque[E]) Forward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for i := 0; i < d.len; i++ {
+			if !yield(d.buf[d.physical(i)]) {
+				break
+			}
+		}
+	}
+}
+
+// Backward creates an [iter.Seq] in backward order.
+func (d *Deque[E]) Backward() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for i := d.len - 1; i >= 0; i-- {
+			if !yield(d.buf[d.physical(i)]) {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package queue_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/collections/queue"
+	"github.com/go-board/std/iter/collector"
+	"github.com/go-board/std/iter/source"
+)
+
+func forward[E any](d *queue.Deque[E]) []E {
+	return collector.Collect(d.Forward(), collector.ToSlice[E]())
+}
+
+func TestDeque_PushPop(t *testing.T) {
+	d := queue.NewDeque[int]()
+	qt.Assert(t, d.PopFront().IsNone(), qt.IsTrue)
+	qt.Assert(t, d.PopBack().IsNone(), qt.IsTrue)
+	qt.Assert(t, d.PeekFront().IsNone(), qt.IsTrue)
+	for i := 0; i < 10; i++ {
+		d.PushBack(i)
+		d.PushFront(-i - 1)
+	}
+	qt.Assert(t, d.Len(), qt.Equals, 20)
+	qt.Assert(t, d.PeekFront().Value(), qt.Equals, -10)
+	qt.Assert(t, d.PeekBack().Value(), qt.Equals, 9)
+	qt.Assert(t, forward(d), qt.DeepEquals, []int{-10, -9, -8, -7, -6, -5, -4, -3, -2, -1, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	qt.Assert(t, collector.Collect(d.Backward(), collector.ToSlice[int]())[:3], qt.DeepEquals, []int{9, 8, 7})
+	for i := 0; i < 10; i++ {
+		qt.Assert(t, d.PopFront().Value(), qt.Equals, -10+i)
+		qt.Assert(t, d.PopBack().Value(), qt.Equals, 9-i)
+	}
+	qt.Assert(t, d.IsEmpty(), qt.IsTrue)
+}
+
+func TestDeque_Wraparound(t *testing.T) {
+	d := queue.NewDequeWithCapacity[int](4)
+	for i := 0; i < 1000; i++ {
+		d.PushBack(i)
+		if i%3 != 0 {
+			d.PopFront()
+		}
+	}
+	qt.Assert(t, d.Len(), qt.Equals, 334)
+	qt.Assert(t, d.Cap() < 1000, qt.IsTrue)
+	got := forward(d)
+	for i := 1; i < len(got); i++ {
+		qt.Assert(t, got[i] > got[i-1], qt.IsTrue)
+	}
+	qt.Assert(t, got[len(got)-1], qt.Equals, 999)
+}
+
+func TestDeque_GetSet(t *testing.T) {
+	d := queue.DequeFromSlice(1, 2, 3)
+	d.PushFront(0)
+	qt.Assert(t, d.Get(0).Value(), qt.Equals, 0)
+	qt.Assert(t, d.Get(3).Value(), qt.Equals, 3)
+	qt.Assert(t, d.Get(4).IsNone(), qt.IsTrue)
+	qt.Assert(t, d.Get(-1).IsNone(), qt.IsTrue)
+	qt.Assert(t, d.Set(2, 20), qt.IsTrue)
+	qt.Assert(t, d.Set(4, 40), qt.IsFalse)
+	qt.Assert(t, forward(d), qt.DeepEquals, []int{0, 1, 20, 3})
+}
+
+func TestDeque_Rotate(t *testing.T) {
+	d := queue.DequeFromSlice(1, 2, 3, 4, 5)
+	d.Rotate(2)
+	qt.Assert(t, forward(d), qt.DeepEquals, []int{3, 4, 5, 1, 2})
+	d.Rotate(-1)
+	qt.Assert(t, forward(d), qt.DeepEquals, []int{2, 3, 4, 5, 1})
+	d.PushBack(6)
+	d.Rotate(5)
+	qt.Assert(t, forward(d), qt.DeepEquals, []int{6, 2, 3, 4, 5, 1})
+	d.Rotate(13)
+	qt.Assert(t, forward(d), qt.DeepEquals, []int{2, 3, 4, 5, 1, 6})
+	queue.NewDeque[int]().Rotate(3)
+}
+
+func TestDeque_TruncateShrink(t *testing.T) {
+	d := queue.DequeFromIter(source.Range1(20))
+	d.PopFront()
+	d.Truncate(5)
+	qt.Assert(t, forward(d), qt.DeepEquals, []int{1, 2, 3, 4, 5})
+	d.Truncate(10)
+	qt.Assert(t, d.Len(), qt.Equals, 5)
+	d.ShrinkToFit()
+	qt.Assert(t, d.Cap(), qt.Equals, 5)
+	qt.Assert(t, forward(d), qt.DeepEquals, []int{1, 2, 3, 4, 5})
+	d.PushBack(6)
+	qt.Assert(t, d.PeekBack().Value(), qt.Equals, 6)
+	d.Clear()
+	qt.Assert(t, d.IsEmpty(), qt.IsTrue)
+	d.ShrinkToFit()
+	qt.Assert(t, d.Cap(), qt.Equals, 0)
+	d.PushFront(1)
+	qt.Assert(t, forward(d), qt.DeepEquals, []int{1})
+}
@@ -11,3 +11,15 @@ func TestArrayQueue_Size(t *testing.T) {
 	q := queue.FromSlice(1, 2)
 	qt.Assert(t, q.Size(), qt.Equals, 2)
 }
+
+func TestArrayQueue_PushPop(t *testing.T) {
+	q := queue.FromSlice(2, 3)
+	q.PushFront(1)
+	q.PushBack(4)
+	qt.Assert(t, q.Size(), qt.Equals, 4)
+	qt.Assert(t, q.PeekFront().Value(), qt.Equals, 1)
+	qt.Assert(t, q.PeekBack().Value(), qt.Equals, 4)
+	qt.Assert(t, q.PopFront().Value(), qt.Equals, 1)
+	qt.Assert(t, q.PopBack().Value(), qt.Equals, 4)
+	qt.Assert(t, q.Size(), qt.Equals, 2)
+}
@@ -21,3 +21,5 @@ type Integer interface{ Signed | Unsigned }
 type Numeric interface{ Integer | Float | Complex }
 
 type Ordered interface{ Integer | Float | ~string }
+
+type Real interface{ Integer | Float }
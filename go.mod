@@ -4,11 +4,11 @@ go 1.18
 
 require (
 	github.com/frankban/quicktest v1.14.6
+	github.com/google/go-cmp v0.5.9
 	github.com/tidwall/btree v1.7.0
 )
 
 require (
-	github.com/google/go-cmp v0.5.9 // indirect
 	github.com/kr/pretty v0.3.1 // indirect
 	github.com/kr/text v0.2.0 // indirect
 	github.com/rogpeppe/go-internal v1.9.0 // indirect
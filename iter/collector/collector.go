@@ -4,6 +4,7 @@ import (
 	"github.com/go-board/std/cmp"
 	"github.com/go-board/std/collections/ordered"
 	"github.com/go-board/std/iter"
+	"github.com/go-board/std/result"
 	"github.com/go-board/std/tuple"
 )
 
@@ -30,6 +31,15 @@ func Collect[E any, C any](seq iter.Seq[E], collector Collector[E, C]) C {
 	return collector.Finish()
 }
 
+// TryCollect collects all elements in [iter.SeqErr] to collector,
+// stopping at the first error and returning it.
+func TryCollect[E any, C any](seq iter.SeqErr[E], collector Collector[E, C]) result.Result[C] {
+	if err := iter.ForEachErr(seq, func(e E) error { collector.Collect(e); return nil }); err != nil {
+		return result.Err[C](err)
+	}
+	return result.Ok(collector.Finish())
+}
+
 type collectorImpl[S, E, C any] struct {
 	state      S
 	collectSeq func(state S, s iter.Seq[E]) S
@@ -5,8 +5,10 @@ import (
 	"testing"
 
 	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/cmp"
 	"github.com/go-board/std/iter"
 	"github.com/go-board/std/iter/collector"
+	"github.com/go-board/std/tuple"
 )
 
 func seq[E any](elems ...E) iter.Seq[E] {
@@ -85,3 +87,99 @@ func TestChunk(t *testing.T) {
 	}), collector.ToSlice[[]int]())
 	qt.Assert(t, x, qt.DeepEquals, [][]int{{1, 2}, {3, 4}, {5}})
 }
+
+func TestTryCollect(t *testing.T) {
+	x := collector.TryCollect(iter.Infallible(seq(1, 2, 3)), collector.ToSlice[int]())
+	qt.Assert(t, x.Value(), qt.DeepEquals, []int{1, 2, 3})
+	y := collector.TryCollect(iter.MapErr(iter.Infallible(seq("1", "e")), strconv.Atoi), collector.ToSlice[int]())
+	qt.Assert(t, y.IsErr(), qt.IsTrue)
+}
+
+func TestStreamDistinct(t *testing.T) {
+	t.Run("unbounded", func(t *testing.T) {
+		x := collector.StreamDistinct(seq(1, 2, 1, 3, 2))
+		qt.Assert(t, collector.Collect(x, collector.ToSlice[int]()), qt.DeepEquals, []int{1, 2, 3})
+	})
+	t.Run("infinite", func(t *testing.T) {
+		cycle := func(yield func(int) bool) {
+			for i := 0; yield(i % 5); i++ {
+			}
+		}
+		x := iter.Take(collector.StreamDistinct[int](cycle), 3)
+		qt.Assert(t, collector.Collect(x, collector.ToSlice[int]()), qt.DeepEquals, []int{0, 1, 2})
+	})
+	t.Run("func", func(t *testing.T) {
+		x := collector.StreamDistinctFunc(seq(1, 2, 1, 3), func(a, b int) int { return a - b })
+		qt.Assert(t, collector.Collect(x, collector.ToSlice[int]()), qt.DeepEquals, []int{1, 2, 3})
+	})
+	t.Run("bounded oldest", func(t *testing.T) {
+		x := collector.StreamDistinctBounded(seq(1, 2, 1, 3, 1, 2), 2, collector.EvictOldest)
+		qt.Assert(t, collector.Collect(x, collector.ToSlice[int]()), qt.DeepEquals, []int{1, 2, 3, 1, 2})
+	})
+	t.Run("bounded least recent", func(t *testing.T) {
+		x := collector.StreamDistinctBounded(seq(1, 2, 1, 3, 1, 2), 2, collector.EvictLeastRecent)
+		qt.Assert(t, collector.Collect(x, collector.ToSlice[int]()), qt.DeepEquals, []int{1, 2, 3, 2})
+	})
+}
+
+func TestStreamChunk(t *testing.T) {
+	m := collector.StreamChunk(seq(1, 2, 3, 4, 5), 2)
+	x := collector.Collect(iter.Map(m, func(e iter.Seq[int]) []int {
+		return collector.Collect(e, collector.ToSlice[int]())
+	}), collector.ToSlice[[]int]())
+	qt.Assert(t, x, qt.DeepEquals, [][]int{{1, 2}, {3, 4}, {5}})
+	qt.Assert(t, iter.Size(iter.Take(m, 1)), qt.Equals, 1)
+}
+
+func TestStreamGroupBy(t *testing.T) {
+	m := collector.StreamGroupBy(seq(1, 3, 2, 5), func(x int) int { return x % 2 })
+	keys := collector.Collect(iter.Map(m, tuple.Pair[int, iter.Seq[int]].First), collector.ToSlice[int]())
+	qt.Assert(t, keys, qt.DeepEquals, []int{1, 0, 1})
+	first, _ := iter.Head(m)
+	qt.Assert(t, collector.Collect(first.Second(), collector.ToSlice[int]()), qt.DeepEquals, []int{1, 3})
+}
+
+func TestCompose(t *testing.T) {
+	t.Run("mapping", func(t *testing.T) {
+		x := collector.Collect(seq(1, 2, 3), collector.Mapping(strconv.Itoa, collector.ToSlice[string]()))
+		qt.Assert(t, x, qt.DeepEquals, []string{"1", "2", "3"})
+	})
+	t.Run("filtering", func(t *testing.T) {
+		x := collector.Collect(seq(1, 2, 3, 4), collector.Filtering(func(x int) bool { return x%2 == 0 }, collector.ToSlice[int]()))
+		qt.Assert(t, x, qt.DeepEquals, []int{2, 4})
+	})
+	t.Run("flat mapping", func(t *testing.T) {
+		x := collector.Collect(seq(1, 2), collector.FlatMapping(func(x int) iter.Seq[int] { return seq(x, x) }, collector.ToSlice[int]()))
+		qt.Assert(t, x, qt.DeepEquals, []int{1, 1, 2, 2})
+	})
+	t.Run("aggregate", func(t *testing.T) {
+		qt.Assert(t, collector.Collect(seq(1, 2, 3), collector.Counting[int]()), qt.Equals, 3)
+		qt.Assert(t, collector.Collect(seq(1, 2, 3), collector.Summing[int]()), qt.Equals, 6)
+		qt.Assert(t, collector.Collect(seq(1, 2, 3, 4), collector.Averaging[int]()), qt.Equals, 2.5)
+		qt.Assert(t, collector.Collect(seq[int](), collector.Averaging[int]()), qt.Equals, 0.0)
+		qt.Assert(t, collector.Collect(seq(1, 2, 3), collector.Reducing(func(x, y int) int { return x * y })).Value(), qt.Equals, 6)
+		qt.Assert(t, collector.Collect(seq(2, 1, 3), collector.MinBy(cmp.Compare[int])).Value(), qt.Equals, 1)
+		qt.Assert(t, collector.Collect(seq(2, 1, 3), collector.MaxBy(cmp.Compare[int])).Value(), qt.Equals, 3)
+		qt.Assert(t, collector.Collect(seq[int](), collector.MaxBy(cmp.Compare[int])).IsNone(), qt.IsTrue)
+	})
+	t.Run("joining", func(t *testing.T) {
+		qt.Assert(t, collector.Collect(seq("a", "b", "c"), collector.Joining(",")), qt.Equals, "a,b,c")
+		qt.Assert(t, collector.Collect(seq("a", "b"), collector.JoiningWith(", ", "[", "]")), qt.Equals, "[a, b]")
+		qt.Assert(t, collector.Collect(seq[string](), collector.JoiningWith(",", "[", "]")), qt.Equals, "[]")
+	})
+	t.Run("grouping", func(t *testing.T) {
+		x := collector.Collect(seq(1, 2, 3, 4, 5), collector.GroupingBy(func(x int) bool { return x%2 == 0 }, collector.Counting[int]))
+		qt.Assert(t, x, qt.DeepEquals, map[bool]int{true: 2, false: 3})
+	})
+	t.Run("partitioning", func(t *testing.T) {
+		x := collector.Collect(seq(1, 2, 3), collector.Partitioning(func(x int) bool { return x%2 == 0 }, collector.ToSlice[int]))
+		qt.Assert(t, x.First(), qt.DeepEquals, []int{2})
+		qt.Assert(t, x.Second(), qt.DeepEquals, []int{1, 3})
+	})
+	t.Run("teeing", func(t *testing.T) {
+		x := collector.Collect(seq(1, 2, 3), collector.Teeing(collector.Summing[int](), collector.Counting[int](), func(sum, n int) float64 {
+			return float64(sum) / float64(n)
+		}))
+		qt.Assert(t, x, qt.Equals, 2.0)
+	})
+}
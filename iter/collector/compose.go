@@ -0,0 +1,252 @@
+package collector
+
+import (
+	"strings"
+
+	"github.com/go-board/std/cmp"
+	"github.com/go-board/std/constraints"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/optional"
+	"github.com/go-board/std/tuple"
+)
+
+// Mapping adapts a [Collector] accepting elements of type T to one accepting
+// elements of type E by applying f to each element before collecting.
+//
+// Example:
+//
+//	collector.Collect(seq(1,2,3), collector.Mapping(strconv.Itoa, collector.ToSlice[string]())) => []string{"1","2","3"}
+func Mapping[E, T, C any](f func(E) T, downstream Collector[T, C]) Collector[E, C] {
+	return newCollectorImpl(
+		downstream,
+		func(state Collector[T, C], s iter.Seq[E]) Collector[T, C] {
+			state.CollectSeq(iter.Map(s, f))
+			return state
+		},
+		func(state Collector[T, C], x E) Collector[T, C] { state.Collect(f(x)); return state },
+		func(state Collector[T, C]) C { return state.Finish() },
+	)
+}
+
+// Filtering adapts a [Collector] by only collecting elements that satisfy the predicate.
+func Filtering[E, C any](f func(E) bool, downstream Collector[E, C]) Collector[E, C] {
+	return newCollectorImpl(
+		downstream,
+		func(state Collector[E, C], s iter.Seq[E]) Collector[E, C] {
+			state.CollectSeq(iter.Filter(s, f))
+			return state
+		},
+		func(state Collector[E, C], x E) Collector[E, C] {
+			if f(x) {
+				state.Collect(x)
+			}
+			return state
+		},
+		func(state Collector[E, C]) C { return state.Finish() },
+	)
+}
+
+// FlatMapping adapts a [Collector] by collecting every element of the [iter.Seq]
+// produced by applying f to each element.
+func FlatMapping[E, T, C any](f func(E) iter.Seq[T], downstream Collector[T, C]) Collector[E, C] {
+	return newCollectorImpl(
+		downstream,
+		func(state Collector[T, C], s iter.Seq[E]) Collector[T, C] {
+			state.CollectSeq(iter.FlatMap(s, f))
+			return state
+		},
+		func(state Collector[T, C], x E) Collector[T, C] { state.CollectSeq(f(x)); return state },
+		func(state Collector[T, C]) C { return state.Finish() },
+	)
+}
+
+// Folding collects all elements by folding them into an accumulator.
+func Folding[E, A any](init A, f func(A, E) A) Collector[E, A] {
+	return newCollectorImpl(
+		init,
+		func(state A, s iter.Seq[E]) A { return iter.Fold(s, state, f) },
+		f,
+		func(state A) A { return state },
+	)
+}
+
+// Reducing collects all elements by repeatedly applying a reducing operation,
+// returns None if no element is collected.
+func Reducing[E any](f func(E, E) E) Collector[E, optional.Optional[E]] {
+	return Folding(optional.None[E](), func(acc optional.Optional[E], e E) optional.Optional[E] {
+		if acc.IsNone() {
+			return optional.Some(e)
+		}
+		return optional.Some(f(acc.Value(), e))
+	})
+}
+
+// Counting counts the number of collected elements.
+func Counting[E any]() Collector[E, int] {
+	return Folding(0, func(n int, _ E) int { return n + 1 })
+}
+
+// Summing sums all collected elements.
+func Summing[N constraints.Numeric]() Collector[N, N] {
+	return Folding(N(0), func(acc N, e N) N { return acc + e })
+}
+
+// Averaging computes the arithmetic mean of all collected elements,
+// returns 0 if no element is collected.
+func Averaging[N constraints.Integer | constraints.Float]() Collector[N, float64] {
+	return newCollectorImpl(
+		tuple.MakePair(0.0, 0),
+		func(state tuple.Pair[float64, int], s iter.Seq[N]) tuple.Pair[float64, int] {
+			return iter.Fold(s, state, average[N])
+		},
+		average[N],
+		func(state tuple.Pair[float64, int]) float64 {
+			if state.Second() == 0 {
+				return 0
+			}
+			return state.First() / float64(state.Second())
+		},
+	)
+}
+
+func average[N constraints.Integer | constraints.Float](state tuple.Pair[float64, int], x N) tuple.Pair[float64, int] {
+	return tuple.MakePair(state.First()+float64(x), state.Second()+1)
+}
+
+// MinBy collects the minimum element with respect to the given comparison function,
+// returns None if no element is collected.
+func MinBy[E any](f func(E, E) int) Collector[E, optional.Optional[E]] {
+	return Reducing(func(x, y E) E { return cmp.MinFunc(f, x, y) })
+}
+
+// MaxBy collects the maximum element with respect to the given comparison function,
+// returns None if no element is collected.
+func MaxBy[E any](f func(E, E) int) Collector[E, optional.Optional[E]] {
+	return Reducing(func(x, y E) E { return cmp.MaxFunc(f, x, y) })
+}
+
+// Joining concatenates all collected strings, separated by sep.
+//
+// Example:
+//
+//	collector.Collect(seq("a","b"), collector.Joining(",")) => "a,b"
+func Joining(sep string) Collector[string, string] {
+	return JoiningWith(sep, "", "")
+}
+
+// JoiningWith concatenates all collected strings, separated by sep,
+// and surrounded by prefix and suffix.
+//
+// Example:
+//
+//	collector.Collect(seq("a","b"), collector.JoiningWith(",", "[", "]")) => "[a,b]"
+func JoiningWith(sep, prefix, suffix string) Collector[string, string] {
+	join := func(state tuple.Pair[*strings.Builder, bool], x string) tuple.Pair[*strings.Builder, bool] {
+		if state.Second() {
+			state.First().WriteString(sep)
+		}
+		state.First().WriteString(x)
+		return tuple.MakePair(state.First(), true)
+	}
+	b := &strings.Builder{}
+	b.WriteString(prefix)
+	return newCollectorImpl(
+		tuple.MakePair(b, false),
+		func(state tuple.Pair[*strings.Builder, bool], s iter.Seq[string]) tuple.Pair[*strings.Builder, bool] {
+			return iter.Fold(s, state, join)
+		},
+		join,
+		func(state tuple.Pair[*strings.Builder, bool]) string { return state.First().String() + suffix },
+	)
+}
+
+// GroupingBy groups collected elements by key, and collects each group using
+// a new downstream [Collector] created by the given function.
+//
+// Example:
+//
+//	collector.Collect(seq(1,2,3), collector.GroupingBy(func(x int) bool { return x%2 == 0 }, collector.Counting[int]))
+//	=> map[bool]int{true: 1, false: 2}
+func GroupingBy[E any, K comparable, C any](f func(E) K, downstream func() Collector[E, C]) Collector[E, map[K]C] {
+	group := func(state map[K]Collector[E, C], x E) map[K]Collector[E, C] {
+		k := f(x)
+		c, ok := state[k]
+		if !ok {
+			c = downstream()
+			state[k] = c
+		}
+		c.Collect(x)
+		return state
+	}
+	return newCollectorImpl(
+		make(map[K]Collector[E, C]),
+		func(state map[K]Collector[E, C], s iter.Seq[E]) map[K]Collector[E, C] {
+			return iter.Fold(s, state, group)
+		},
+		group,
+		func(state map[K]Collector[E, C]) map[K]C {
+			rs := make(map[K]C, len(state))
+			for k, c := range state {
+				rs[k] = c.Finish()
+			}
+			return rs
+		},
+	)
+}
+
+// Partitioning splits collected elements by the given predicate, and collects
+// each part using a new downstream [Collector] created by the given function.
+//
+// The first result collects elements that satisfies the predicate,
+// the second result collects elements that not satisfies the predicate.
+//
+// Example:
+//
+//	collector.Collect(seq(1,2,3), collector.Partitioning(func(x int) bool { return x%2 == 0 }, collector.ToSlice[int]))
+//	=> pair([]int{2}, []int{1,3})
+func Partitioning[E, C any](f func(E) bool, downstream func() Collector[E, C]) Collector[E, tuple.Pair[C, C]] {
+	part := func(state tuple.Pair[Collector[E, C], Collector[E, C]], x E) tuple.Pair[Collector[E, C], Collector[E, C]] {
+		if f(x) {
+			state.First().Collect(x)
+		} else {
+			state.Second().Collect(x)
+		}
+		return state
+	}
+	return newCollectorImpl(
+		tuple.MakePair(downstream(), downstream()),
+		func(state tuple.Pair[Collector[E, C], Collector[E, C]], s iter.Seq[E]) tuple.Pair[Collector[E, C], Collector[E, C]] {
+			return iter.Fold(s, state, part)
+		},
+		part,
+		func(state tuple.Pair[Collector[E, C], Collector[E, C]]) tuple.Pair[C, C] {
+			return tuple.MakePair(state.First().Finish(), state.Second().Finish())
+		},
+	)
+}
+
+// Teeing collects every element into two [Collector] in a single pass,
+// and merges their results using the given function.
+//
+// Example:
+//
+//	collector.Collect(seq(1,2,3), collector.Teeing(collector.Summing[int](), collector.Counting[int](), func(sum, n int) float64 {
+//		return float64(sum) / float64(n)
+//	})) => 2.0
+func Teeing[E, C1, C2, R any](c1 Collector[E, C1], c2 Collector[E, C2], merge func(C1, C2) R) Collector[E, R] {
+	tee := func(state tuple.Pair[Collector[E, C1], Collector[E, C2]], x E) tuple.Pair[Collector[E, C1], Collector[E, C2]] {
+		state.First().Collect(x)
+		state.Second().Collect(x)
+		return state
+	}
+	return newCollectorImpl(
+		tuple.MakePair(c1, c2),
+		func(state tuple.Pair[Collector[E, C1], Collector[E, C2]], s iter.Seq[E]) tuple.Pair[Collector[E, C1], Collector[E, C2]] {
+			return iter.Fold(s, state, tee)
+		},
+		tee,
+		func(state tuple.Pair[Collector[E, C1], Collector[E, C2]]) R {
+			return merge(state.First().Finish(), state.Second().Finish())
+		},
+	)
+}
@@ -0,0 +1,131 @@
+package collector
+
+import (
+	"container/list"
+
+	"github.com/go-board/std/collections/ordered"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/tuple"
+)
+
+// Eviction is the policy used by a memory bounded collector to choose
+// which remembered element to forget when it is full.
+type Eviction int
+
+const (
+	// EvictOldest forgets the element that was seen first.
+	EvictOldest Eviction = iota
+	// EvictLeastRecent forgets the element that was seen least recently,
+	// seeing a duplicate again refreshes it.
+	EvictLeastRecent
+)
+
+// StreamDistinct remove duplicated elements in [iter.Seq] and
+// yield the first occurrence of each element immediately.
+//
+// Unlike [Distinct], it doesn't buffer the input, so it works on unbounded [iter.Seq],
+// but memory grows with the number of distinct elements, see [StreamDistinctBounded].
+//
+// Example:
+//
+//	collector.StreamDistinct(seq(1,2,1,3,2)) => seq: 1,2,3
+func StreamDistinct[E comparable](s iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		seen := make(map[E]struct{})
+		s(func(e E) bool {
+			if _, ok := seen[e]; ok {
+				return true
+			}
+			seen[e] = struct{}{}
+			return yield(e)
+		})
+	}
+}
+
+// StreamDistinctFunc is like [StreamDistinct], but compares elements using the given function.
+func StreamDistinctFunc[E any](s iter.Seq[E], f func(E, E) int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		seen := ordered.NewSet(f)
+		s(func(e E) bool {
+			if seen.Contains(e) {
+				return true
+			}
+			seen.Insert(e)
+			return yield(e)
+		})
+	}
+}
+
+// StreamDistinctBounded is like [StreamDistinct], but remembers at most max elements.
+//
+// When the remembered set is full, an element is forgotten according to the eviction policy,
+// so a duplicate that was forgotten will be yielded again.
+// If max less-equal than 0, it behaves like [StreamDistinct].
+func StreamDistinctBounded[E comparable](s iter.Seq[E], max int, policy Eviction) iter.Seq[E] {
+	if max <= 0 {
+		return StreamDistinct(s)
+	}
+	return func(yield func(E) bool) {
+		seen := make(map[E]*list.Element, max)
+		order := list.New()
+		s(func(e E) bool {
+			if elem, ok := seen[e]; ok {
+				if policy == EvictLeastRecent {
+					order.MoveToBack(elem)
+				}
+				return true
+			}
+			if order.Len() >= max {
+				delete(seen, order.Remove(order.Front()).(E))
+			}
+			seen[e] = order.PushBack(e)
+			return yield(e)
+		})
+	}
+}
+
+// StreamChunk splits [iter.Seq] into chunks of n elements,
+// and yield each chunk as soon as it is full.
+//
+// The last chunk may be shorter than n.
+// If n less-equal than 0, yield nothing.
+//
+// Example:
+//
+//	collector.StreamChunk(seq(1,2,3,4,5), 2) => seq: seq(1,2), seq(3,4), seq(5)
+func StreamChunk[E any](s iter.Seq[E], n int) iter.Seq[iter.Seq[E]] {
+	return func(yield func(iter.Seq[E]) bool) {
+		if n <= 0 {
+			return
+		}
+		chunk := make([]E, 0, n)
+		ok := true
+		s(func(e E) bool {
+			chunk = append(chunk, e)
+			if len(chunk) == n {
+				ok = yield(sliceSeq(chunk))
+				chunk = make([]E, 0, n)
+			}
+			return ok
+		})
+		if ok && len(chunk) > 0 {
+			yield(sliceSeq(chunk))
+		}
+	}
+}
+
+// StreamGroupBy groups adjacent elements that share the same key,
+// and yield each group as soon as the key changes.
+//
+// Unlike [GroupBy], equal keys that are not adjacent produce separate groups,
+// so sort or partition the input by key first if a single group per key is required.
+//
+// Example:
+//
+//	collector.StreamGroupBy(seq(1,3,2,5), func(x int) int { return x%2 })
+//	=> seq: pair(1, seq(1,3)), pair(0, seq(2)), pair(1, seq(5))
+func StreamGroupBy[E any, K comparable](s iter.Seq[E], f func(E) K) iter.Seq[tuple.Pair[K, iter.Seq[E]]] {
+	return iter.Map(iter.GroupAdjacent(s, f), func(p tuple.Pair[K, []E]) tuple.Pair[K, iter.Seq[E]] {
+		return tuple.MakePair(p.First(), sliceSeq(p.Second()))
+	})
+}
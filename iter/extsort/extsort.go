@@ -0,0 +1,358 @@
+// Package extsort sorts sequences larger than memory using an external merge sort.
+//
+// Elements are buffered into sorted runs of bounded size, each run is spilled
+// to a temporary file through a [Codec], and the runs are merged lazily.
+package extsort
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/go-board/std/cmp"
+	"github.com/go-board/std/iter"
+)
+
+// Codec encodes elements to and decodes elements from a spilled run.
+type Codec[E any] interface {
+	// NewEncoder returns a function that writes an element to w.
+	NewEncoder(w io.Writer) func(E) error
+	// NewDecoder returns a function that reads the next element from r,
+	// it returns [io.EOF] when no more elements are available.
+	NewDecoder(r io.Reader) func() (E, error)
+}
+
+type gobCodec[E any] struct{}
+
+func (gobCodec[E]) NewEncoder(w io.Writer) func(E) error {
+	enc := gob.NewEncoder(w)
+	return func(e E) error { return enc.Encode(e) }
+}
+
+func (gobCodec[E]) NewDecoder(r io.Reader) func() (E, error) {
+	dec := gob.NewDecoder(r)
+	return func() (e E, err error) { err = dec.Decode(&e); return }
+}
+
+// GobCodec returns a [Codec] using [encoding/gob].
+func GobCodec[E any]() Codec[E] { return gobCodec[E]{} }
+
+type jsonCodec[E any] struct{}
+
+func (jsonCodec[E]) NewEncoder(w io.Writer) func(E) error {
+	enc := json.NewEncoder(w)
+	return func(e E) error { return enc.Encode(e) }
+}
+
+func (jsonCodec[E]) NewDecoder(r io.Reader) func() (E, error) {
+	dec := json.NewDecoder(r)
+	return func() (e E, err error) { err = dec.Decode(&e); return }
+}
+
+// JSONCodec returns a [Codec] using [encoding/json].
+func JSONCodec[E any]() Codec[E] { return jsonCodec[E]{} }
+
+// DefaultFanIn is the number of runs a [Sorter] merges at once unless set by [Sorter.WithFanIn].
+const DefaultFanIn = 64
+
+// Sorter sorts sequences using an external merge sort.
+type Sorter[E any] struct {
+	cmp     func(E, E) int
+	runSize int
+	fanIn   int
+	codec   Codec[E]
+	dir     string
+}
+
+// New creates a [Sorter] which keeps at most runSize elements in memory
+// while building runs, and spills runs to the default temporary directory.
+//
+// If runSize less than 1, it's set to 1.
+func New[E any](cmp func(E, E) int, runSize int, codec Codec[E]) *Sorter[E] {
+	if runSize < 1 {
+		runSize = 1
+	}
+	return &Sorter[E]{cmp: cmp, runSize: runSize, fanIn: DefaultFanIn, codec: codec}
+}
+
+// WithDir returns a copy of [Sorter] which spills runs into dir.
+func (s *Sorter[E]) WithDir(dir string) *Sorter[E] {
+	x := *s
+	x.dir = dir
+	return &x
+}
+
+// WithFanIn returns a copy of [Sorter] which merges at most n spilled runs at once,
+// so at most n temporary files are open at the same time.
+// More runs are merged in several passes, each pass merging groups of n runs into one.
+//
+// If n less than 2, it's set to 2.
+func (s *Sorter[E]) WithFanIn(n int) *Sorter[E] {
+	if n < 2 {
+		n = 2
+	}
+	x := *s
+	x.fanIn = n
+	return &x
+}
+
+// Sort returns a [iter.SeqErr] that yields elements of seq in sorted order.
+//
+// The sort is stable. Temporary files are removed when iteration finishes,
+// whether it completes, fails or stops early.
+func (s *Sorter[E]) Sort(seq iter.Seq[E]) iter.SeqErr[E] {
+	return func(yield func(E, error) bool) {
+		var zero E
+		// every file ever created, removing an already removed one is harmless.
+		var created []string
+		defer func() {
+			for _, name := range created {
+				os.Remove(name)
+			}
+		}()
+
+		var runs []string
+		buf := make([]E, 0, s.runSize)
+		var err error
+		seq(func(e E) bool {
+			buf = append(buf, e)
+			if len(buf) < s.runSize {
+				return true
+			}
+			var name string
+			if name, err = s.spill(buf); err == nil {
+				created = append(created, name)
+				runs = append(runs, name)
+			}
+			buf = buf[:0]
+			return err == nil
+		})
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		s.sortRun(buf)
+		if len(runs) == 0 {
+			for _, e := range buf {
+				if !yield(e, nil) {
+					return
+				}
+			}
+			return
+		}
+		for len(runs) > s.fanIn {
+			if runs, err = s.mergePass(runs, &created); err != nil {
+				yield(zero, err)
+				return
+			}
+		}
+		nexts, closeRuns, err := s.openRuns(runs)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer closeRuns()
+		s.mergeRuns(append(nexts, sliceNext(buf)), yield)
+	}
+}
+
+func (s *Sorter[E]) sortRun(run []E) {
+	sort.SliceStable(run, func(i, j int) bool { return s.cmp(run[i], run[j]) < 0 })
+}
+
+// spill sorts run and writes it to a new temporary file, returns the name of the file.
+func (s *Sorter[E]) spill(run []E) (string, error) {
+	s.sortRun(run)
+	return s.writeRun(func(encode func(E) error) error {
+		for _, e := range run {
+			if err := encode(e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// writeRun creates a temporary file written by write, the file is removed if writing fails.
+func (s *Sorter[E]) writeRun(write func(encode func(E) error) error) (name string, err error) {
+	f, err := os.CreateTemp(s.dir, "extsort-*")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(f.Name())
+			name = ""
+		}
+	}()
+	w := bufio.NewWriter(f)
+	if err = write(s.codec.NewEncoder(w)); err != nil {
+		return "", err
+	}
+	return f.Name(), w.Flush()
+}
+
+// openRuns opens spilled runs for reading, the returned function closes all of them.
+func (s *Sorter[E]) openRuns(names []string) ([]func() (E, error), func(), error) {
+	var files []*os.File
+	closeAll := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+	nexts := make([]func() (E, error), 0, len(names)+1)
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		files = append(files, f)
+		nexts = append(nexts, s.codec.NewDecoder(bufio.NewReader(f)))
+	}
+	return nexts, closeAll, nil
+}
+
+// mergePass merges consecutive groups of fanIn runs into one run each, and returns the new runs.
+// Merging consecutive groups in order keeps the sort stable.
+func (s *Sorter[E]) mergePass(runs []string, created *[]string) ([]string, error) {
+	next := make([]string, 0, (len(runs)+s.fanIn-1)/s.fanIn)
+	for i := 0; i < len(runs); i += s.fanIn {
+		j := i + s.fanIn
+		if j > len(runs) {
+			j = len(runs)
+		}
+		group := runs[i:j]
+		if len(group) == 1 {
+			next = append(next, group[0])
+			continue
+		}
+		name, err := s.mergeGroup(group)
+		if err != nil {
+			return nil, err
+		}
+		*created = append(*created, name)
+		for _, g := range group {
+			os.Remove(g)
+		}
+		next = append(next, name)
+	}
+	return next, nil
+}
+
+// mergeGroup merges runs into a new spilled run.
+func (s *Sorter[E]) mergeGroup(group []string) (string, error) {
+	nexts, closeRuns, err := s.openRuns(group)
+	if err != nil {
+		return "", err
+	}
+	defer closeRuns()
+	return s.writeRun(func(encode func(E) error) error {
+		var werr error
+		s.mergeRuns(nexts, func(e E, err error) bool {
+			if err == nil {
+				err = encode(e)
+			}
+			werr = err
+			return err == nil
+		})
+		return werr
+	})
+}
+
+// run is the head of a sorted run during merge, index breaks ties to keep the sort stable.
+type run[E any] struct {
+	head  E
+	index int
+	next  func() (E, error)
+}
+
+type runHeap[E any] struct {
+	runs []run[E]
+	cmp  func(E, E) int
+}
+
+func (h *runHeap[E]) Len() int { return len(h.runs) }
+func (h *runHeap[E]) Less(i, j int) bool {
+	if c := h.cmp(h.runs[i].head, h.runs[j].head); c != 0 {
+		return c < 0
+	}
+	return h.runs[i].index < h.runs[j].index
+}
+func (h *runHeap[E]) Swap(i, j int) { h.runs[i], h.runs[j] = h.runs[j], h.runs[i] }
+func (h *runHeap[E]) Push(x any)    { h.runs = append(h.runs, x.(run[E])) }
+func (h *runHeap[E]) Pop() any {
+	x := h.runs[len(h.runs)-1]
+	h.runs = h.runs[:len(h.runs)-1]
+	return x
+}
+
+func sliceNext[E any](elems []E) func() (E, error) {
+	return func() (e E, err error) {
+		if len(elems) == 0 {
+			return e, io.EOF
+		}
+		e, elems = elems[0], elems[1:]
+		return e, nil
+	}
+}
+
+// mergeRuns merges sorted runs and yields elements in sorted order,
+// equal elements are yielded in the order of their runs.
+func (s *Sorter[E]) mergeRuns(nexts []func() (E, error), yield func(E, error) bool) {
+	var zero E
+	h := &runHeap[E]{cmp: s.cmp}
+	for i, next := range nexts {
+		e, err := next()
+		if errors.Is(err, io.EOF) {
+			continue
+		}
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		h.runs = append(h.runs, run[E]{head: e, index: i, next: next})
+	}
+	heap.Init(h)
+	for h.Len() > 0 {
+		r := &h.runs[0]
+		if !yield(r.head, nil) {
+			return
+		}
+		e, err := r.next()
+		switch {
+		case errors.Is(err, io.EOF):
+			heap.Pop(h)
+		case err != nil:
+			yield(zero, err)
+			return
+		default:
+			r.head = e
+			heap.Fix(h, 0)
+		}
+	}
+}
+
+// Sort sorts seq in ascending order using an external merge sort,
+// keeping at most runSize elements in memory while building runs.
+//
+// Example:
+//
+//	extsort.Sort(seq(3,1,2), 2, extsort.GobCodec[int]()) => seqErr: 1,2,3
+func Sort[E cmp.Ordered](seq iter.Seq[E], runSize int, codec Codec[E]) iter.SeqErr[E] {
+	return SortFunc(seq, cmp.Compare[E], runSize, codec)
+}
+
+// SortFunc sorts seq in order of the given compare function using an external merge sort,
+// keeping at most runSize elements in memory while building runs.
+func SortFunc[E any](seq iter.Seq[E], f func(E, E) int, runSize int, codec Codec[E]) iter.SeqErr[E] {
+	return New(f, runSize, codec).Sort(seq)
+}
@@ -0,0 +1,81 @@
+package extsort_test
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/iter/extsort"
+	"github.com/go-board/std/iter/source"
+)
+
+func TestSort(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	data := make([]int, 1000)
+	for i := range data {
+		data[i] = r.Intn(100)
+	}
+	x, err := iter.CollectErr(extsort.Sort(source.Variadic(data...), 64, extsort.GobCodec[int]()))
+	qt.Assert(t, err, qt.IsNil)
+	sort.Ints(data)
+	qt.Assert(t, x, qt.DeepEquals, data)
+
+	t.Run("in memory", func(t *testing.T) {
+		x, err := iter.CollectErr(extsort.Sort(source.Variadic(3, 1, 2), 10, extsort.GobCodec[int]()))
+		qt.Assert(t, err, qt.IsNil)
+		qt.Assert(t, x, qt.DeepEquals, []int{1, 2, 3})
+	})
+	t.Run("stable", func(t *testing.T) {
+		type kv struct{ K, V int }
+		s := iter.Map(source.Range1(20), func(i int) kv { return kv{K: i % 3, V: i} })
+		x, err := iter.CollectErr(extsort.SortFunc(s, func(a, b kv) int { return a.K - b.K }, 4, extsort.JSONCodec[kv]()))
+		qt.Assert(t, err, qt.IsNil)
+		qt.Assert(t, x, qt.HasLen, 20)
+		qt.Assert(t, sort.SliceIsSorted(x, func(i, j int) bool {
+			return x[i].K < x[j].K || (x[i].K == x[j].K && x[i].V < x[j].V)
+		}), qt.IsTrue)
+	})
+	t.Run("cleanup", func(t *testing.T) {
+		dir := t.TempDir()
+		sorter := extsort.New(func(a, b int) int { return a - b }, 4, extsort.GobCodec[int]()).WithDir(dir)
+		x, err := iter.CollectErr(iter.TakeErr(sorter.Sort(source.Variadic(data...)), 3))
+		qt.Assert(t, err, qt.IsNil)
+		qt.Assert(t, x, qt.DeepEquals, data[:3])
+		entries, err := os.ReadDir(dir)
+		qt.Assert(t, err, qt.IsNil)
+		qt.Assert(t, entries, qt.HasLen, 0)
+	})
+	t.Run("multi-pass merge", func(t *testing.T) {
+		type kv struct{ K, V int }
+		dir := t.TempDir()
+		s := iter.Map(source.Range1(200), func(i int) kv { return kv{K: (i * 7) % 5, V: i} })
+		sorter := extsort.New(func(a, b kv) int { return a.K - b.K }, 3, extsort.GobCodec[kv]()).WithDir(dir).WithFanIn(2)
+		x, err := iter.CollectErr(sorter.Sort(s))
+		qt.Assert(t, err, qt.IsNil)
+		qt.Assert(t, x, qt.HasLen, 200)
+		qt.Assert(t, sort.SliceIsSorted(x, func(i, j int) bool {
+			return x[i].K < x[j].K || (x[i].K == x[j].K && x[i].V < x[j].V)
+		}), qt.IsTrue)
+		entries, err := os.ReadDir(dir)
+		qt.Assert(t, err, qt.IsNil)
+		qt.Assert(t, entries, qt.HasLen, 0)
+	})
+	t.Run("codec error", func(t *testing.T) {
+		_, err := iter.CollectErr(extsort.Sort(source.Variadic(3, 2, 1), 1, extsort.Codec[int](failCodec{})))
+		qt.Assert(t, err, qt.Equals, errEncode)
+	})
+}
+
+var errEncode = errors.New("encode")
+
+type failCodec struct{}
+
+func (failCodec) NewEncoder(io.Writer) func(int) error { return func(int) error { return errEncode } }
+func (failCodec) NewDecoder(io.Reader) func() (int, error) {
+	return func() (int, error) { return 0, io.EOF }
+}
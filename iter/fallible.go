@@ -0,0 +1,139 @@
+package iter
+
+import (
+	"github.com/go-board/std/result"
+)
+
+// SeqErr is an iterator over sequences of individual values paired with an error.
+//
+// When called as seq(yield), seq calls yield(v, nil) for each value v in the sequence,
+// a producer that failed calls yield(zero, err) once and then stops,
+// stopping early if yield returns false.
+//
+// All combinators over SeqErr short-circuit on the first error,
+// forward it to the consumer and stop.
+type SeqErr[E any] func(yield func(E, error) bool)
+
+// Infallible converts a [Seq] into a [SeqErr] that never fails.
+func Infallible[E any](s Seq[E]) SeqErr[E] {
+	return func(yield func(E, error) bool) {
+		s(func(e E) bool { return yield(e, nil) })
+	}
+}
+
+// FromResults converts a [Seq] of [result.Result] into a [SeqErr].
+//
+// The returned [SeqErr] stops after the first error result.
+func FromResults[E any](s Seq[result.Result[E]]) SeqErr[E] {
+	return func(yield func(E, error) bool) {
+		s(func(r result.Result[E]) bool {
+			e, err := r.Get()
+			return yield(e, err) && err == nil
+		})
+	}
+}
+
+// ToResults converts a [SeqErr] into a [Seq] of [result.Result].
+//
+// Example:
+//
+//	iter.ToResults(seqErr(1, err)) => seq: Ok(1), Err(err)
+func ToResults[E any](s SeqErr[E]) Seq[result.Result[E]] {
+	return func(yield func(result.Result[E]) bool) {
+		s(func(e E, err error) bool { return yield(result.FromPair(e, err)) && err == nil })
+	}
+}
+
+// MapErr call f on each element in [SeqErr], and map each element to another type.
+//
+// Stopping at the first error either from the [SeqErr] or from f.
+//
+// Example:
+//
+//	iter.MapErr(seqErr("1","e","3"), strconv.Atoi) => seqErr: (1, nil), (0, err)
+func MapErr[E, T any](s SeqErr[E], f func(E) (T, error)) SeqErr[T] {
+	return func(yield func(T, error) bool) {
+		s(func(e E, err error) bool {
+			var t T
+			if err == nil {
+				t, err = f(e)
+			}
+			return yield(t, err) && err == nil
+		})
+	}
+}
+
+// FilterErr creates an iterator which uses a closure to
+// determine if an element should be yielded.
+//
+// Errors are always yielded, and stop the iteration.
+func FilterErr[E any](s SeqErr[E], f func(E) bool) SeqErr[E] {
+	return func(yield func(E, error) bool) {
+		s(func(e E, err error) bool {
+			if err != nil {
+				yield(e, err)
+				return false
+			}
+			if f(e) {
+				return yield(e, nil)
+			}
+			return true
+		})
+	}
+}
+
+// TakeErr creates an iterator that yields the first `n` elements, or fewer
+// if the underlying iterator ends or fails sooner.
+func TakeErr[E any](s SeqErr[E], n int) SeqErr[E] {
+	return func(yield func(E, error) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		s(func(e E, err error) bool {
+			i++
+			return yield(e, err) && err == nil && i < n
+		})
+	}
+}
+
+// ForEachErr call f on each element in [SeqErr],
+// stopping at the first error and returning that error.
+func ForEachErr[E any](s SeqErr[E], f func(E) error) (err error) {
+	s(func(e E, e2 error) bool {
+		if err = e2; err == nil {
+			err = f(e)
+		}
+		return err == nil
+	})
+	return
+}
+
+// FoldErr folds each element into an accumulator by applying an operation,
+// stopping at the first error and returning that error.
+func FoldErr[E, A any](s SeqErr[E], init A, f func(A, E) (A, error)) (res A, err error) {
+	res = init
+	s(func(e E, e2 error) bool {
+		if err = e2; err == nil {
+			res, err = f(res, e)
+		}
+		return err == nil
+	})
+	return
+}
+
+// CollectErr collects all elements in [SeqErr] into a slice,
+// stopping at the first error and returning the elements collected before it.
+//
+// Example:
+//
+//	iter.CollectErr(seqErr(1, 2)) => []int{1, 2}, nil
+//	iter.CollectErr(seqErr(1, err)) => []int{1}, err
+func CollectErr[E any](s SeqErr[E]) ([]E, error) {
+	return FoldErr(s, make([]E, 0), func(acc []E, e E) ([]E, error) { return append(acc, e), nil })
+}
+
+// CollectErrResult is like [CollectErr], but returns a [result.Result].
+func CollectErrResult[E any](s SeqErr[E]) result.Result[[]E] {
+	return result.FromPair(CollectErr(s))
+}
@@ -0,0 +1,74 @@
+package iter_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/result"
+)
+
+var errBoom = errors.New("boom")
+
+func seqErr(elems ...any) iter.SeqErr[int] {
+	return func(yield func(int, error) bool) {
+		for _, e := range elems {
+			switch x := e.(type) {
+			case int:
+				if !yield(x, nil) {
+					return
+				}
+			case error:
+				yield(0, x)
+				return
+			}
+		}
+	}
+}
+
+func TestSeqErr(t *testing.T) {
+	t.Run("collect", func(t *testing.T) {
+		x, err := iter.CollectErr(seqErr(1, 2, 3))
+		qt.Assert(t, err, qt.IsNil)
+		qt.Assert(t, x, qt.DeepEquals, []int{1, 2, 3})
+		y, err := iter.CollectErr(seqErr(1, errBoom, 3))
+		qt.Assert(t, err, qt.Equals, errBoom)
+		qt.Assert(t, y, qt.DeepEquals, []int{1})
+	})
+	t.Run("map", func(t *testing.T) {
+		s := iter.MapErr(iter.Infallible(seq("1", "e", "3")), strconv.Atoi)
+		x, err := iter.CollectErr(s)
+		qt.Assert(t, err, qt.ErrorMatches, `.*invalid syntax`)
+		qt.Assert(t, x, qt.DeepEquals, []int{1})
+	})
+	t.Run("filter", func(t *testing.T) {
+		s := iter.FilterErr(seqErr(1, 2, 3, 4, errBoom, 6), func(x int) bool { return x%2 == 0 })
+		x, err := iter.CollectErr(s)
+		qt.Assert(t, err, qt.Equals, errBoom)
+		qt.Assert(t, x, qt.DeepEquals, []int{2, 4})
+	})
+	t.Run("take", func(t *testing.T) {
+		x, err := iter.CollectErr(iter.TakeErr(seqErr(1, 2, errBoom), 2))
+		qt.Assert(t, err, qt.IsNil)
+		qt.Assert(t, x, qt.DeepEquals, []int{1, 2})
+		_, err = iter.CollectErr(iter.TakeErr(seqErr(1, errBoom), 5))
+		qt.Assert(t, err, qt.Equals, errBoom)
+	})
+	t.Run("fold", func(t *testing.T) {
+		x, err := iter.FoldErr(seqErr(1, 2, 3), 0, func(a, e int) (int, error) { return a + e, nil })
+		qt.Assert(t, err, qt.IsNil)
+		qt.Assert(t, x, qt.Equals, 6)
+		err = iter.ForEachErr(seqErr(1, errBoom), func(int) error { return nil })
+		qt.Assert(t, err, qt.Equals, errBoom)
+	})
+	t.Run("results", func(t *testing.T) {
+		rs := collect(iter.ToResults(seqErr(1, errBoom, 3)))
+		qt.Assert(t, len(rs), qt.Equals, 2)
+		qt.Assert(t, rs[0].Value(), qt.Equals, 1)
+		qt.Assert(t, rs[1].Error(), qt.Equals, errBoom)
+		x := iter.CollectErrResult(iter.FromResults(seq(result.Ok(1), result.Err[int](errBoom), result.Ok(3))))
+		qt.Assert(t, x.IsErr(), qt.IsTrue)
+	})
+}
@@ -6,3 +6,10 @@ package iter
 //
 // see: https://github.com/golang/go/issues/61897
 type Seq[E any] func(yield func(E) bool)
+
+// Seq2 is an iterator over sequences of pairs of values, most commonly key-value pairs.
+// When called as seq(yield), seq calls yield(k, v) for each pair (k, v) in the sequence,
+// stopping early if yield returns false.
+//
+// see: https://github.com/golang/go/issues/61897
+type Seq2[K, V any] func(yield func(K, V) bool)
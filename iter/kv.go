@@ -0,0 +1,102 @@
+package iter
+
+import (
+	"github.com/go-board/std/tuple"
+)
+
+// Keys creates a [Seq] over the keys of [Seq2].
+//
+// Example:
+//
+//	iter.Keys(seq2((1,"a"), (2,"b"))) => seq: 1,2
+func Keys[K, V any](s Seq2[K, V]) Seq[K] {
+	return func(yield func(K) bool) {
+		s(func(k K, _ V) bool { return yield(k) })
+	}
+}
+
+// Values creates a [Seq] over the values of [Seq2].
+//
+// Example:
+//
+//	iter.Values(seq2((1,"a"), (2,"b"))) => seq: "a","b"
+func Values[K, V any](s Seq2[K, V]) Seq[V] {
+	return func(yield func(V) bool) {
+		s(func(_ K, v V) bool { return yield(v) })
+	}
+}
+
+// MapKV call f on each k-v pair in [Seq2], and map each pair to another pair.
+//
+// Example:
+//
+//	iter.MapKV(seq2((1,"a"), (2,"b")), func(k int, v string) (string, int) { return v, k * 2 }) => seq2: ("a",2), ("b",4)
+func MapKV[K, V, X, Y any](s Seq2[K, V], f func(K, V) (X, Y)) Seq2[X, Y] {
+	return func(yield func(X, Y) bool) {
+		s(func(k K, v V) bool { return yield(f(k, v)) })
+	}
+}
+
+// FilterKV creates a [Seq2] which uses a closure to
+// determine if a k-v pair should be yielded.
+//
+// Example:
+//
+//	iter.FilterKV(seq2((1,"a"), (2,"b")), func(k int, v string) bool { return k > 1 }) => seq2: (2,"b")
+func FilterKV[K, V any](s Seq2[K, V], f func(K, V) bool) Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s(func(k K, v V) bool {
+			if f(k, v) {
+				return yield(k, v)
+			}
+			return true
+		})
+	}
+}
+
+// ForEachKV call f on each k-v pair in [Seq2].
+func ForEachKV[K, V any](s Seq2[K, V], f func(K, V)) {
+	s(func(k K, v V) bool { f(k, v); return true })
+}
+
+// Swap creates a [Seq2] which swaps key and value of each pair.
+//
+// Example:
+//
+//	iter.Swap(seq2((1,"a"), (2,"b"))) => seq2: ("a",1), ("b",2)
+func Swap[K, V any](s Seq2[K, V]) Seq2[V, K] {
+	return func(yield func(V, K) bool) {
+		s(func(k K, v V) bool { return yield(v, k) })
+	}
+}
+
+// ToPairs converts a [Seq2] into a [Seq] of [tuple.Pair].
+//
+// Example:
+//
+//	iter.ToPairs(seq2((1,"a"), (2,"b"))) => seq: pair(1,"a"), pair(2,"b")
+func ToPairs[K, V any](s Seq2[K, V]) Seq[tuple.Pair[K, V]] {
+	return func(yield func(tuple.Pair[K, V]) bool) {
+		s(func(k K, v V) bool { return yield(tuple.MakePair(k, v)) })
+	}
+}
+
+// FromPairs converts a [Seq] of [tuple.Pair] into a [Seq2].
+//
+// Example:
+//
+//	iter.FromPairs(seq(pair(1,"a"), pair(2,"b"))) => seq2: (1,"a"), (2,"b")
+func FromPairs[K, V any](s Seq[tuple.Pair[K, V]]) Seq2[K, V] {
+	return FromFunc(s, tuple.Pair[K, V].Unpack)
+}
+
+// FromFunc converts a [Seq] into a [Seq2] by splitting each element using the given function.
+//
+// Example:
+//
+//	iter.FromFunc(seq(user{id: 1, name: "a"}), func(u user) (int, string) { return u.id, u.name }) => seq2: (1,"a")
+func FromFunc[E, K, V any](s Seq[E], f func(E) (K, V)) Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s(func(e E) bool { return yield(f(e)) })
+	}
+}
@@ -0,0 +1,52 @@
+package iter_test
+
+import (
+	"strconv"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/tuple"
+)
+
+func seq2(keys []int, values []string) iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for i := range keys {
+			if !yield(keys[i], values[i]) {
+				break
+			}
+		}
+	}
+}
+
+func TestSeq2(t *testing.T) {
+	s := seq2([]int{1, 2, 3}, []string{"a", "b", "c"})
+	t.Run("keys & values", func(t *testing.T) {
+		qt.Assert(t, collect(iter.Keys(s)), qt.DeepEquals, []int{1, 2, 3})
+		qt.Assert(t, collect(iter.Values(s)), qt.DeepEquals, []string{"a", "b", "c"})
+	})
+	t.Run("map", func(t *testing.T) {
+		x := iter.MapKV(s, func(k int, v string) (string, int) { return v + strconv.Itoa(k), k * 2 })
+		qt.Assert(t, collect(iter.Keys(x)), qt.DeepEquals, []string{"a1", "b2", "c3"})
+		qt.Assert(t, collect(iter.Values(x)), qt.DeepEquals, []int{2, 4, 6})
+	})
+	t.Run("filter", func(t *testing.T) {
+		x := iter.FilterKV(s, func(k int, v string) bool { return k != 2 })
+		qt.Assert(t, collect(iter.Values(x)), qt.DeepEquals, []string{"a", "c"})
+	})
+	t.Run("swap", func(t *testing.T) {
+		x := iter.Swap(s)
+		qt.Assert(t, collect(iter.Keys(x)), qt.DeepEquals, []string{"a", "b", "c"})
+	})
+	t.Run("pairs", func(t *testing.T) {
+		x := collect(iter.ToPairs(s))
+		qt.Assert(t, x[1].First(), qt.Equals, 2)
+		qt.Assert(t, x[1].Second(), qt.Equals, "b")
+		y := iter.FromPairs(seq(tuple.MakePair(1, "a"), tuple.MakePair(2, "b")))
+		qt.Assert(t, collect(iter.Keys(y)), qt.DeepEquals, []int{1, 2})
+	})
+	t.Run("early stop", func(t *testing.T) {
+		x := iter.Take(iter.Values(s), 2)
+		qt.Assert(t, collect(x), qt.DeepEquals, []string{"a", "b"})
+	})
+}
@@ -0,0 +1,222 @@
+package iter
+
+import (
+	"container/heap"
+
+	"github.com/go-board/std/cmp"
+	"github.com/go-board/std/optional"
+	"github.com/go-board/std/tuple"
+)
+
+// mergeHead is the current head element of one input of a k-way merge.
+type mergeHead[E any] struct {
+	elem  E
+	index int
+	next  func() (E, bool)
+}
+
+type mergeHeap[E any] struct {
+	heads []mergeHead[E]
+	cmp   func(E, E) int
+}
+
+func (h *mergeHeap[E]) Len() int { return len(h.heads) }
+func (h *mergeHeap[E]) Less(i, j int) bool {
+	if c := h.cmp(h.heads[i].elem, h.heads[j].elem); c != 0 {
+		return c < 0
+	}
+	return h.heads[i].index < h.heads[j].index
+}
+func (h *mergeHeap[E]) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+func (h *mergeHeap[E]) Push(x any)    { h.heads = append(h.heads, x.(mergeHead[E])) }
+func (h *mergeHeap[E]) Pop() any {
+	x := h.heads[len(h.heads)-1]
+	h.heads = h.heads[:len(h.heads)-1]
+	return x
+}
+
+// MergeSorted merges many sorted [Seq] into a single sorted [Seq].
+//
+// Example:
+//
+//	iter.MergeSorted(seq(1,4,7), seq(2,5), seq(3,6)) => seq: 1,2,3,4,5,6,7
+func MergeSorted[E cmp.Ordered](seqs ...Seq[E]) Seq[E] {
+	return MergeSortedFunc(cmp.Compare[E], seqs...)
+}
+
+// MergeSortedFunc merges many [Seq] sorted by the given compare function
+// into a single sorted [Seq].
+//
+// The merge is stable, equal elements are yielded in the order of their input [Seq].
+// Each input is consumed lazily, only the head of each input is buffered.
+func MergeSortedFunc[E any](f func(E, E) int, seqs ...Seq[E]) Seq[E] {
+	return func(yield func(E) bool) {
+		h := &mergeHeap[E]{cmp: f}
+		for i, s := range seqs {
+			next, stop := pull(s)
+			defer stop()
+			if e, ok := next(); ok {
+				h.heads = append(h.heads, mergeHead[E]{elem: e, index: i, next: next})
+			}
+		}
+		heap.Init(h)
+		for h.Len() > 0 {
+			head := &h.heads[0]
+			if !yield(head.elem) {
+				return
+			}
+			if e, ok := head.next(); ok {
+				head.elem = e
+				heap.Fix(h, 0)
+			} else {
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+// mergeJoin walks two sorted [Seq] side by side, calling onLeft for elements only in x,
+// onRight for elements only in y, and onBoth for elements in both.
+func mergeJoin[E any](x, y Seq[E], f func(E, E) int, onLeft, onRight, onBoth func(E) bool) {
+	nx, sx := pull(x)
+	defer sx()
+	ny, sy := pull(y)
+	defer sy()
+	ex, okx := nx()
+	ey, oky := ny()
+	for okx && oky {
+		c := f(ex, ey)
+		switch {
+		case c < 0:
+			if !onLeft(ex) {
+				return
+			}
+			ex, okx = nx()
+		case c > 0:
+			if !onRight(ey) {
+				return
+			}
+			ey, oky = ny()
+		default:
+			if !onBoth(ex) {
+				return
+			}
+			ex, okx = nx()
+			ey, oky = ny()
+		}
+	}
+	for ; okx; ex, okx = nx() {
+		if !onLeft(ex) {
+			return
+		}
+	}
+	for ; oky; ey, oky = ny() {
+		if !onRight(ey) {
+			return
+		}
+	}
+}
+
+func skipAll[E any](E) bool { return true }
+
+// UnionSorted yields elements in either of two sorted [Seq], in sorted order.
+//
+// Elements that appear in both [Seq] are yielded once.
+//
+// Example:
+//
+//	iter.UnionSorted(seq(1,3,5), seq(1,2,3)) => seq: 1,2,3,5
+func UnionSorted[E cmp.Ordered](x, y Seq[E]) Seq[E] {
+	return UnionSortedFunc(x, y, cmp.Compare[E])
+}
+
+// UnionSortedFunc is like [UnionSorted], but compares elements using the given function.
+func UnionSortedFunc[E any](x, y Seq[E], f func(E, E) int) Seq[E] {
+	return func(yield func(E) bool) { mergeJoin(x, y, f, yield, yield, yield) }
+}
+
+// IntersectSorted yields elements in both of two sorted [Seq], in sorted order.
+//
+// Example:
+//
+//	iter.IntersectSorted(seq(1,3,5), seq(1,2,3)) => seq: 1,3
+func IntersectSorted[E cmp.Ordered](x, y Seq[E]) Seq[E] {
+	return IntersectSortedFunc(x, y, cmp.Compare[E])
+}
+
+// IntersectSortedFunc is like [IntersectSorted], but compares elements using the given function.
+func IntersectSortedFunc[E any](x, y Seq[E], f func(E, E) int) Seq[E] {
+	return func(yield func(E) bool) { mergeJoin(x, y, f, skipAll[E], skipAll[E], yield) }
+}
+
+// ExceptSorted yields elements in the first sorted [Seq] but not in the second one,
+// in sorted order.
+//
+// Example:
+//
+//	iter.ExceptSorted(seq(1,3,5), seq(1,2,3)) => seq: 5
+func ExceptSorted[E cmp.Ordered](x, y Seq[E]) Seq[E] {
+	return ExceptSortedFunc(x, y, cmp.Compare[E])
+}
+
+// ExceptSortedFunc is like [ExceptSorted], but compares elements using the given function.
+func ExceptSortedFunc[E any](x, y Seq[E], f func(E, E) int) Seq[E] {
+	return func(yield func(E) bool) { mergeJoin(x, y, f, yield, skipAll[E], skipAll[E]) }
+}
+
+func indexBy[B any, K comparable](s Seq[B], key func(B) K) map[K][]B {
+	index := make(map[K][]B)
+	ForEach(s, func(b B) { k := key(b); index[k] = append(index[k], b) })
+	return index
+}
+
+// InnerJoin yields a pair for every element in x and element in y that share the same key.
+//
+// y is buffered into a hash index on first iteration, x is streamed,
+// so pass the smaller input as y. Pairs are yielded in the order of x.
+//
+// Example:
+//
+//	iter.InnerJoin(seq(user{1,"a"}, user{2,"b"}), seq(order{1,"x"}), user.ID, order.UserID)
+//	=> seq: pair(user{1,"a"}, order{1,"x"})
+func InnerJoin[A, B any, K comparable](x Seq[A], y Seq[B], keyA func(A) K, keyB func(B) K) Seq[tuple.Pair[A, B]] {
+	return func(yield func(tuple.Pair[A, B]) bool) {
+		index := indexBy(y, keyB)
+		x(func(a A) bool {
+			for _, b := range index[keyA(a)] {
+				if !yield(tuple.MakePair(a, b)) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+// LeftJoin yields a pair for every element in x and element in y that share the same key,
+// and a pair with None for every element in x that has no match in y.
+//
+// y is buffered into a hash index on first iteration, x is streamed.
+// Pairs are yielded in the order of x.
+//
+// Example:
+//
+//	iter.LeftJoin(seq(user{1,"a"}, user{2,"b"}), seq(order{1,"x"}), user.ID, order.UserID)
+//	=> seq: pair(user{1,"a"}, Some(order{1,"x"})), pair(user{2,"b"}, None)
+func LeftJoin[A, B any, K comparable](x Seq[A], y Seq[B], keyA func(A) K, keyB func(B) K) Seq[tuple.Pair[A, optional.Optional[B]]] {
+	return func(yield func(tuple.Pair[A, optional.Optional[B]]) bool) {
+		index := indexBy(y, keyB)
+		x(func(a A) bool {
+			bs := index[keyA(a)]
+			if len(bs) == 0 {
+				return yield(tuple.MakePair(a, optional.None[B]()))
+			}
+			for _, b := range bs {
+				if !yield(tuple.MakePair(a, optional.Some(b))) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
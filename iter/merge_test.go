@@ -0,0 +1,57 @@
+package iter_test
+
+import (
+	"runtime"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/tuple"
+)
+
+func TestMergeSorted(t *testing.T) {
+	x := iter.MergeSorted(seq(1, 4, 7), seq(2, 5), seq(3, 6), seq[int]())
+	qt.Assert(t, collect(x), qt.DeepEquals, []int{1, 2, 3, 4, 5, 6, 7})
+	qt.Assert(t, collect(iter.MergeSorted[int]()), qt.IsNil)
+	t.Run("stable", func(t *testing.T) {
+		type kv = tuple.Pair[int, string]
+		byKey := func(a, b kv) int { return a.First() - b.First() }
+		x := iter.MergeSortedFunc(byKey, seq(tuple.MakePair(1, "a"), tuple.MakePair(2, "a")), seq(tuple.MakePair(1, "b")))
+		qt.Assert(t, collect(iter.Map(x, kv.Second)), qt.DeepEquals, []string{"a", "b", "a"})
+	})
+	t.Run("early stop", func(t *testing.T) {
+		n := runtime.NumGoroutine()
+		x := iter.MergeSorted(naturals(), naturals())
+		qt.Assert(t, collect(iter.Take(x, 4)), qt.DeepEquals, []int{0, 0, 1, 1})
+		waitGoroutines(t, n)
+	})
+}
+
+func TestSortedSetOps(t *testing.T) {
+	x, y := seq(1, 3, 5, 7), seq(1, 2, 3, 8)
+	qt.Assert(t, collect(iter.UnionSorted(x, y)), qt.DeepEquals, []int{1, 2, 3, 5, 7, 8})
+	qt.Assert(t, collect(iter.IntersectSorted(x, y)), qt.DeepEquals, []int{1, 3})
+	qt.Assert(t, collect(iter.ExceptSorted(x, y)), qt.DeepEquals, []int{5, 7})
+	qt.Assert(t, collect(iter.ExceptSorted(y, x)), qt.DeepEquals, []int{2, 8})
+	qt.Assert(t, collect(iter.Take(iter.UnionSorted(x, y), 2)), qt.DeepEquals, []int{1, 2})
+}
+
+func TestJoin(t *testing.T) {
+	type user = tuple.Pair[int, string]
+	type order = tuple.Pair[int, string]
+	users := seq(tuple.MakePair(1, "alice"), tuple.MakePair(2, "bob"))
+	orders := seq(tuple.MakePair(1, "book"), tuple.MakePair(1, "pen"), tuple.MakePair(3, "cup"))
+	t.Run("inner", func(t *testing.T) {
+		x := iter.Map(iter.InnerJoin(users, orders, user.First, order.First), func(p tuple.Pair[user, order]) string {
+			return p.First().Second() + ":" + p.Second().Second()
+		})
+		qt.Assert(t, collect(x), qt.DeepEquals, []string{"alice:book", "alice:pen"})
+	})
+	t.Run("left", func(t *testing.T) {
+		x := collect(iter.LeftJoin(users, orders, user.First, order.First))
+		qt.Assert(t, len(x), qt.Equals, 3)
+		qt.Assert(t, x[1].Second().Value().Second(), qt.Equals, "pen")
+		qt.Assert(t, x[2].First().Second(), qt.Equals, "bob")
+		qt.Assert(t, x[2].Second().IsNone(), qt.IsTrue)
+	})
+}
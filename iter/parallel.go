@@ -0,0 +1,211 @@
+package iter
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// parResult carries the output of a parallel stage back to the consumer,
+// including a panic raised by the user function on a worker goroutine.
+type parResult[T any] struct {
+	value    T
+	panicked bool
+	panicVal any
+}
+
+func parCall[E, T any](f func(E) T, e E) (r parResult[T]) {
+	defer func() {
+		if p := recover(); p != nil {
+			r.panicked, r.panicVal = true, p
+		}
+	}()
+	r.value = f(e)
+	return
+}
+
+func parWorkers(n int) int {
+	if n <= 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return n
+}
+
+// ParMap call f on each element in [Seq] using n worker goroutines,
+// and yield mapped elements in the same order as the input [Seq].
+//
+// If n less-equal than 0, [runtime.GOMAXPROCS] workers are used.
+//
+// Iteration stops when ctx is done or when the consumer stops early,
+// in both cases all worker goroutines have exited before the returned [Seq] returns.
+// A panic in f is re-raised on the consuming goroutine.
+//
+// Example:
+//
+//	iter.ParMap(ctx, seq(1,2,3), 2, func(x int) int { return x * x }) => seq: 1,4,9
+func ParMap[E, T any](ctx context.Context, s Seq[E], n int, f func(E) T) Seq[T] {
+	type job struct {
+		elem E
+		res  chan parResult[T]
+	}
+	return func(yield func(T) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		n := parWorkers(n)
+		jobs := make(chan job)
+		order := make(chan chan parResult[T], n)
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		defer cancel()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(order)
+			defer close(jobs)
+			s(func(e E) bool {
+				res := make(chan parResult[T], 1)
+				select {
+				case order <- res:
+				case <-ctx.Done():
+					return false
+				}
+				select {
+				case jobs <- job{elem: e, res: res}:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			})
+		}()
+		wg.Add(n)
+		for i := 0; i < n; i++ {
+			go func() {
+				defer wg.Done()
+				for j := range jobs {
+					j.res <- parCall(f, j.elem)
+				}
+			}()
+		}
+
+		for res := range order {
+			select {
+			case r := <-res:
+				if r.panicked {
+					cancel()
+					wg.Wait()
+					panic(r.panicVal)
+				}
+				if !yield(r.value) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// ParMapUnordered call f on each element in [Seq] using n worker goroutines,
+// and yield mapped elements as soon as they are ready.
+//
+// The output order is unspecified, which avoids waiting on slow elements
+// and gives better throughput than [ParMap].
+//
+// Cancellation, cleanup and panic semantics are the same as [ParMap].
+func ParMapUnordered[E, T any](ctx context.Context, s Seq[E], n int, f func(E) T) Seq[T] {
+	return func(yield func(T) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		n := parWorkers(n)
+		jobs := make(chan E)
+		out := make(chan parResult[T], n)
+		var wg sync.WaitGroup
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(jobs)
+			s(func(e E) bool {
+				select {
+				case jobs <- e:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			})
+		}()
+		wg.Add(n)
+		for i := 0; i < n; i++ {
+			go func() {
+				defer wg.Done()
+				for e := range jobs {
+					select {
+					case out <- parCall(f, e):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+		go func() { wg.Wait(); close(out) }()
+		// drain out until every goroutine has exited.
+		defer func() {
+			for range out {
+			}
+		}()
+		defer cancel()
+
+		for {
+			select {
+			case r, ok := <-out:
+				if !ok {
+					return
+				}
+				if r.panicked {
+					cancel()
+					for range out {
+					}
+					panic(r.panicVal)
+				}
+				if !yield(r.value) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// ParFilter tests each element in [Seq] using n worker goroutines,
+// and yield elements that satisfy the predicate in the same order as the input [Seq].
+//
+// Cancellation, cleanup and panic semantics are the same as [ParMap].
+//
+// Example:
+//
+//	iter.ParFilter(ctx, seq(1,2,3,4), 2, func(x int) bool { return x%2 == 0 }) => seq: 2,4
+func ParFilter[E any](ctx context.Context, s Seq[E], n int, f func(E) bool) Seq[E] {
+	return parFilter(ParMap(ctx, s, n, parKeep(f)))
+}
+
+// ParFilterUnordered is like [ParFilter], but yield elements as soon as they are tested.
+func ParFilterUnordered[E any](ctx context.Context, s Seq[E], n int, f func(E) bool) Seq[E] {
+	return parFilter(ParMapUnordered(ctx, s, n, parKeep(f)))
+}
+
+type parKept[E any] struct {
+	elem E
+	keep bool
+}
+
+func parKeep[E any](f func(E) bool) func(E) parKept[E] {
+	return func(e E) parKept[E] { return parKept[E]{elem: e, keep: f(e)} }
+}
+
+func parFilter[E any](s Seq[parKept[E]]) Seq[E] {
+	return FilterMap(s, func(k parKept[E]) (E, bool) { return k.elem, k.keep })
+}
@@ -0,0 +1,88 @@
+package iter_test
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/iter"
+)
+
+func naturals() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+}
+
+func waitGoroutines(t *testing.T, n int) {
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > n && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	qt.Assert(t, runtime.NumGoroutine() <= n, qt.IsTrue)
+}
+
+func TestParMap(t *testing.T) {
+	ctx := context.Background()
+	square := func(x int) int { return x * x }
+	t.Run("ordered", func(t *testing.T) {
+		s := iter.ParMap(ctx, seq(1, 2, 3, 4, 5), 3, square)
+		qt.Assert(t, collect(s), qt.DeepEquals, []int{1, 4, 9, 16, 25})
+	})
+	t.Run("unordered", func(t *testing.T) {
+		x := collect(iter.ParMapUnordered(ctx, seq(1, 2, 3, 4, 5), 3, square))
+		sort.Ints(x)
+		qt.Assert(t, x, qt.DeepEquals, []int{1, 4, 9, 16, 25})
+	})
+	t.Run("fold", func(t *testing.T) {
+		x := iter.Fold(iter.ParMap(ctx, seq(1, 2, 3), 0, square), 0, func(a, e int) int { return a + e })
+		qt.Assert(t, x, qt.Equals, 14)
+	})
+	t.Run("early stop", func(t *testing.T) {
+		n := runtime.NumGoroutine()
+		x := collect(iter.Take(iter.ParMap(ctx, naturals(), 4, square), 3))
+		qt.Assert(t, x, qt.DeepEquals, []int{0, 1, 4})
+		y := collect(iter.Take(iter.ParMapUnordered(ctx, naturals(), 4, square), 3))
+		qt.Assert(t, len(y), qt.Equals, 3)
+		waitGoroutines(t, n)
+	})
+	t.Run("cancel", func(t *testing.T) {
+		n := runtime.NumGoroutine()
+		ctx, cancel := context.WithCancel(ctx)
+		s := iter.ParMap(ctx, naturals(), 4, func(x int) int {
+			if x == 10 {
+				cancel()
+			}
+			return x
+		})
+		qt.Assert(t, iter.Size(s) < 20, qt.IsTrue)
+		waitGoroutines(t, n)
+	})
+	t.Run("panic", func(t *testing.T) {
+		s := iter.ParMap(ctx, seq(1, 2, 3), 2, func(x int) int {
+			if x == 2 {
+				panic("boom")
+			}
+			return x
+		})
+		qt.Assert(t, func() { collect(s) }, qt.PanicMatches, "boom")
+	})
+}
+
+func TestParFilter(t *testing.T) {
+	ctx := context.Background()
+	even := func(x int) bool { return x%2 == 0 }
+	t.Run("ordered", func(t *testing.T) {
+		s := iter.ParFilter(ctx, seq(1, 2, 3, 4, 5, 6), 2, even)
+		qt.Assert(t, collect(s), qt.DeepEquals, []int{2, 4, 6})
+	})
+	t.Run("unordered", func(t *testing.T) {
+		x := collect(iter.ParFilterUnordered(ctx, seq(1, 2, 3, 4, 5, 6), 2, even))
+		sort.Ints(x)
+		qt.Assert(t, x, qt.DeepEquals, []int{2, 4, 6})
+	})
+}
@@ -0,0 +1,52 @@
+package iter
+
+import (
+	"sync"
+)
+
+// pull turn a [Seq] into a pull style iterator and a stop function,
+// without relying on the rangefunc experiment.
+//
+// [Seq] runs on a separate goroutine, a panic raised by it is re-raised by next.
+// stop must be called once the iterator is no longer needed,
+// it waits for the goroutine to exit.
+func pull[E any](s Seq[E]) (next func() (E, bool), stop func()) {
+	values := make(chan E)
+	done := make(chan struct{})
+	var (
+		panicked bool
+		panicVal any
+		once     sync.Once
+	)
+	go func() {
+		defer close(values)
+		defer func() {
+			if p := recover(); p != nil {
+				panicked, panicVal = true, p
+			}
+		}()
+		s(func(e E) bool {
+			select {
+			case values <- e:
+				return true
+			case <-done:
+				return false
+			}
+		})
+	}()
+	next = func() (E, bool) {
+		e, ok := <-values
+		if !ok && panicked {
+			panic(panicVal)
+		}
+		return e, ok
+	}
+	stop = func() {
+		once.Do(func() {
+			close(done)
+			for range values {
+			}
+		})
+	}
+	return next, stop
+}
@@ -0,0 +1,140 @@
+package iter
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/go-board/std/cmp"
+)
+
+func sortedSeq[E any](s Seq[E], f func(E, E) int, stable bool) Seq[E] {
+	return func(yield func(E) bool) {
+		elems := Fold(s, make([]E, 0), func(acc []E, e E) []E { return append(acc, e) })
+		less := func(i, j int) bool { return f(elems[i], elems[j]) < 0 }
+		if stable {
+			sort.SliceStable(elems, less)
+		} else {
+			sort.Slice(elems, less)
+		}
+		for _, e := range elems {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted creates an iterator which yields elements of [Seq] in ascending order.
+//
+// The whole [Seq] is buffered and sorted lazily, on each iteration.
+//
+// Example:
+//
+//	iter.Sorted(seq(3,1,2)) => seq: 1,2,3
+func Sorted[E cmp.Ordered](s Seq[E]) Seq[E] {
+	return SortedFunc(s, cmp.Compare[E])
+}
+
+// SortedFunc creates an iterator which yields elements of [Seq] in order of the given compare function.
+//
+// The sort is not guaranteed to be stable, see [SortedStableFunc].
+func SortedFunc[E any](s Seq[E], f func(E, E) int) Seq[E] {
+	return sortedSeq(s, f, false)
+}
+
+// SortedByKey creates an iterator which yields elements of [Seq] in ascending order
+// of the key produced by the given function.
+//
+// The sort is not guaranteed to be stable, see [SortedStableByKey].
+func SortedByKey[E any, K cmp.Ordered](s Seq[E], f func(E) K) Seq[E] {
+	return SortedFunc(s, func(x, y E) int { return cmp.Compare(f(x), f(y)) })
+}
+
+// SortedStableFunc is like [SortedFunc], but keeps the original order of equal elements.
+func SortedStableFunc[E any](s Seq[E], f func(E, E) int) Seq[E] {
+	return sortedSeq(s, f, true)
+}
+
+// SortedStableByKey is like [SortedByKey], but keeps the original order of equal elements.
+func SortedStableByKey[E any, K cmp.Ordered](s Seq[E], f func(E) K) Seq[E] {
+	return SortedStableFunc(s, func(x, y E) int { return cmp.Compare(f(x), f(y)) })
+}
+
+// boundedHeap keeps at most k elements, the root is the element to be evicted first.
+type boundedHeap[E any] struct {
+	elems []E
+	less  func(E, E) bool
+}
+
+func (h *boundedHeap[E]) Len() int           { return len(h.elems) }
+func (h *boundedHeap[E]) Less(i, j int) bool { return h.less(h.elems[i], h.elems[j]) }
+func (h *boundedHeap[E]) Swap(i, j int)      { h.elems[i], h.elems[j] = h.elems[j], h.elems[i] }
+func (h *boundedHeap[E]) Push(x any)         { h.elems = append(h.elems, x.(E)) }
+func (h *boundedHeap[E]) Pop() any {
+	x := h.elems[len(h.elems)-1]
+	h.elems = h.elems[:len(h.elems)-1]
+	return x
+}
+
+// topK returns the k greatest elements in descending order, using O(k) memory.
+func topK[E any](s Seq[E], k int, f func(E, E) int) []E {
+	if k <= 0 {
+		return nil
+	}
+	h := &boundedHeap[E]{elems: make([]E, 0, k), less: func(x, y E) bool { return f(x, y) < 0 }}
+	ForEach(s, func(e E) {
+		if h.Len() < k {
+			heap.Push(h, e)
+		} else if f(e, h.elems[0]) > 0 {
+			h.elems[0] = e
+			heap.Fix(h, 0)
+		}
+	})
+	rs := make([]E, h.Len())
+	for i := len(rs) - 1; i >= 0; i-- {
+		rs[i] = heap.Pop(h).(E)
+	}
+	return rs
+}
+
+func sliceSeq[E any](elems []E) Seq[E] {
+	return func(yield func(E) bool) {
+		for _, e := range elems {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// TopK creates an iterator which yields the k greatest elements of [Seq] in descending order.
+//
+// Only k elements are kept in memory at any time.
+//
+// Example:
+//
+//	iter.TopK(seq(3,1,4,1,5,9,2), 3) => seq: 9,5,4
+func TopK[E cmp.Ordered](s Seq[E], k int) Seq[E] {
+	return TopKFunc(s, k, cmp.Compare[E])
+}
+
+// TopKFunc is like [TopK], but compares elements using the given function.
+func TopKFunc[E any](s Seq[E], k int, f func(E, E) int) Seq[E] {
+	return func(yield func(E) bool) { sliceSeq(topK(s, k, f))(yield) }
+}
+
+// BottomK creates an iterator which yields the k least elements of [Seq] in ascending order.
+//
+// Only k elements are kept in memory at any time.
+//
+// Example:
+//
+//	iter.BottomK(seq(3,1,4,1,5,9,2), 3) => seq: 1,1,2
+func BottomK[E cmp.Ordered](s Seq[E], k int) Seq[E] {
+	return BottomKFunc(s, k, cmp.Compare[E])
+}
+
+// BottomKFunc is like [BottomK], but compares elements using the given function.
+func BottomKFunc[E any](s Seq[E], k int, f func(E, E) int) Seq[E] {
+	return TopKFunc(s, k, func(x, y E) int { return f(y, x) })
+}
@@ -0,0 +1,34 @@
+package iter_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/cmp"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/tuple"
+)
+
+func TestSorted(t *testing.T) {
+	qt.Assert(t, collect(iter.Sorted(seq(3, 1, 2))), qt.DeepEquals, []int{1, 2, 3})
+	qt.Assert(t, collect(iter.SortedFunc(seq(3, 1, 2), func(x, y int) int { return y - x })), qt.DeepEquals, []int{3, 2, 1})
+	qt.Assert(t, collect(iter.SortedByKey(seq("ccc", "a", "bb"), func(x string) int { return len(x) })), qt.DeepEquals, []string{"a", "bb", "ccc"})
+	qt.Assert(t, collect(iter.Take(iter.Sorted(seq(3, 1, 2)), 1)), qt.DeepEquals, []int{1})
+	t.Run("stable", func(t *testing.T) {
+		type kv = tuple.Pair[int, string]
+		s := seq(tuple.MakePair(2, "a"), tuple.MakePair(1, "b"), tuple.MakePair(2, "c"), tuple.MakePair(1, "d"))
+		x := iter.SortedStableByKey(s, kv.First)
+		qt.Assert(t, collect(iter.Map(x, kv.Second)), qt.DeepEquals, []string{"b", "d", "a", "c"})
+	})
+}
+
+func TestTopK(t *testing.T) {
+	s := seq(3, 1, 4, 1, 5, 9, 2, 6)
+	qt.Assert(t, collect(iter.TopK(s, 3)), qt.DeepEquals, []int{9, 6, 5})
+	qt.Assert(t, collect(iter.BottomK(s, 3)), qt.DeepEquals, []int{1, 1, 2})
+	qt.Assert(t, collect(iter.TopK(s, 10)), qt.DeepEquals, []int{9, 6, 5, 4, 3, 2, 1, 1})
+	qt.Assert(t, collect(iter.TopK(s, 0)), qt.IsNil)
+	qt.Assert(t, collect(iter.TopKFunc(seq("a", "ccc", "bb"), 1, func(x, y string) int {
+		return cmp.Compare(len(x), len(y))
+	})), qt.DeepEquals, []string{"ccc"})
+}
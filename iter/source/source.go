@@ -1,6 +1,9 @@
 package source
 
 import (
+	"bufio"
+	"io"
+
 	"github.com/go-board/std/constraints"
 	"github.com/go-board/std/iter"
 	"github.com/go-board/std/tuple"
@@ -39,6 +42,23 @@ func Chan[E any](c <-chan E) iter.Seq[E] {
 	}
 }
 
+// Lines create an [iter.SeqErr] of lines read from [io.Reader].
+//
+// Line terminators are stripped, and a read error is yielded as the last element.
+func Lines(r io.Reader) iter.SeqErr[string] {
+	return func(yield func(string, error) bool) {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			if !yield(scanner.Text(), nil) {
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			yield("", err)
+		}
+	}
+}
+
 // Map create an [iter.Seq] of k-v pair from map type.
 //
 // The pair order is unordered.
@@ -52,6 +72,19 @@ func Map[K comparable, V any, M ~map[K]V](m M) iter.Seq[tuple.Pair[K, V]] {
 	}
 }
 
+// MapKV create an [iter.Seq2] of k-v from map type.
+//
+// The pair order is unordered.
+func MapKV[K comparable, V any, M ~map[K]V](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
 // Variadic creates an [iter.Seq] from variadic elements.
 //
 // Example:
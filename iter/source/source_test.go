@@ -1,6 +1,7 @@
 package source_test
 
 import (
+	"strings"
 	"testing"
 
 	qt "github.com/frankban/quicktest"
@@ -49,3 +50,20 @@ func TestOnce(t *testing.T) {
 func TestVariadic(t *testing.T) {
 	qt.Assert(t, collect(source.Variadic(1, 2, 3)), qt.DeepEquals, []int{1, 2, 3})
 }
+
+func TestLines(t *testing.T) {
+	x, err := iter.CollectErr(source.Lines(strings.NewReader("a\nb\n\nc")))
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, x, qt.DeepEquals, []string{"a", "b", "", "c"})
+}
+
+func TestMapKV(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	got := map[string]int{}
+	source.MapKV(m)(func(k string, v int) bool { got[k] = v; return true })
+	qt.Assert(t, got, qt.DeepEquals, m)
+
+	n := 0
+	source.MapKV(m)(func(string, int) bool { n++; return false })
+	qt.Assert(t, n, qt.Equals, 1)
+}
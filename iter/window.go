@@ -0,0 +1,143 @@
+package iter
+
+import (
+	"github.com/go-board/std/tuple"
+)
+
+// Windows creates an iterator over all contiguous windows of length n,
+// the windows overlap.
+//
+// Each window is a newly allocated slice, it's safe to keep it after yield returns.
+// If [Seq] is shorter than n, or n less-equal than 0, yield nothing.
+//
+// Example:
+//
+//	iter.Windows(seq(1,2,3,4), 2) => seq: [1,2], [2,3], [3,4]
+//	iter.Windows(seq(1,2), 3)     => seq:
+func Windows[E any](s Seq[E], n int) Seq[[]E] {
+	return SlidingBy(s, n, 1)
+}
+
+// SlidingBy creates an iterator over windows of length size,
+// each window starts step elements after the previous one.
+//
+// If step less than size, windows overlap. If step greater than size,
+// elements between windows are skipped. Trailing elements that don't fill
+// a whole window are dropped.
+// If size or step less-equal than 0, yield nothing.
+//
+// Example:
+//
+//	iter.SlidingBy(seq(1,2,3,4,5), 3, 2) => seq: [1,2,3], [3,4,5]
+//	iter.SlidingBy(seq(1,2,3,4,5), 1, 2) => seq: [1], [3], [5]
+func SlidingBy[E any](s Seq[E], size int, step int) Seq[[]E] {
+	return func(yield func([]E) bool) {
+		if size <= 0 || step <= 0 {
+			return
+		}
+		buf := make([]E, 0, size)
+		skip := 0
+		s(func(e E) bool {
+			if skip > 0 {
+				skip--
+				return true
+			}
+			buf = append(buf, e)
+			if len(buf) < size {
+				return true
+			}
+			window := make([]E, size)
+			copy(window, buf)
+			if step >= size {
+				skip = step - size
+				buf = buf[:0]
+			} else {
+				buf = append(buf[:0], buf[step:]...)
+			}
+			return yield(window)
+		})
+	}
+}
+
+// Pairwise creates an iterator over each pair of adjacent elements.
+//
+// Example:
+//
+//	iter.Pairwise(seq(1,2,3)) => seq: pair(1,2), pair(2,3)
+//	iter.Pairwise(seq(1))     => seq:
+func Pairwise[E any](s Seq[E]) Seq[tuple.Pair[E, E]] {
+	return func(yield func(tuple.Pair[E, E]) bool) {
+		var prev E
+		var started bool
+		s(func(e E) bool {
+			defer func() { prev = e }()
+			if !started {
+				started = true
+				return true
+			}
+			return yield(tuple.MakePair(prev, e))
+		})
+	}
+}
+
+// ChunkBy creates an iterator over runs of elements,
+// a new run starts whenever f returns false for two adjacent elements.
+//
+// Each run is a newly allocated slice, it's safe to keep it after yield returns.
+//
+// Example:
+//
+//	iter.ChunkBy(seq(1,2,4,5,7), func(x, y int) bool { return y == x+1 }) => seq: [1,2], [4,5], [7]
+func ChunkBy[E any](s Seq[E], f func(prev E, next E) bool) Seq[[]E] {
+	return func(yield func([]E) bool) {
+		var chunk []E
+		ok := true
+		s(func(e E) bool {
+			if len(chunk) > 0 && !f(chunk[len(chunk)-1], e) {
+				ok = yield(chunk)
+				chunk = nil
+				if !ok {
+					return false
+				}
+			}
+			chunk = append(chunk, e)
+			return true
+		})
+		if ok && len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// GroupAdjacent creates an iterator over runs of elements that share the same key,
+// a new group starts whenever the key changes.
+//
+// Unlike a hash based group by, equal keys that are not adjacent produce separate groups.
+//
+// Example:
+//
+//	iter.GroupAdjacent(seq(1,3,2,4,5), func(x int) bool { return x%2 == 0 })
+//	=> seq: pair(false, [1,3]), pair(true, [2,4]), pair(false, [5])
+func GroupAdjacent[E any, K comparable](s Seq[E], f func(E) K) Seq[tuple.Pair[K, []E]] {
+	return func(yield func(tuple.Pair[K, []E]) bool) {
+		var key K
+		var group []E
+		ok := true
+		s(func(e E) bool {
+			k := f(e)
+			if len(group) > 0 && k != key {
+				ok = yield(tuple.MakePair(key, group))
+				group = nil
+				if !ok {
+					return false
+				}
+			}
+			key = k
+			group = append(group, e)
+			return true
+		})
+		if ok && len(group) > 0 {
+			yield(tuple.MakePair(key, group))
+		}
+	}
+}
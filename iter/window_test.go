@@ -0,0 +1,49 @@
+package iter_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/tuple"
+	cmp2 "github.com/google/go-cmp/cmp"
+)
+
+var pairComparer = cmp2.Comparer(func(l, r tuple.Pair[int, int]) bool {
+	return l.First() == r.First() && l.Second() == r.Second()
+})
+
+func TestWindows(t *testing.T) {
+	qt.Assert(t, collect(iter.Windows(seq(1, 2, 3, 4), 2)), qt.DeepEquals, [][]int{{1, 2}, {2, 3}, {3, 4}})
+	qt.Assert(t, collect(iter.Windows(seq(1, 2), 3)), qt.IsNil)
+	qt.Assert(t, collect(iter.Windows(seq(1, 2), 0)), qt.IsNil)
+	qt.Assert(t, collect(iter.Take(iter.Windows(seq(1, 2, 3, 4), 3), 1)), qt.DeepEquals, [][]int{{1, 2, 3}})
+}
+
+func TestSlidingBy(t *testing.T) {
+	qt.Assert(t, collect(iter.SlidingBy(seq(1, 2, 3, 4, 5), 3, 2)), qt.DeepEquals, [][]int{{1, 2, 3}, {3, 4, 5}})
+	qt.Assert(t, collect(iter.SlidingBy(seq(1, 2, 3, 4, 5), 2, 2)), qt.DeepEquals, [][]int{{1, 2}, {3, 4}})
+	qt.Assert(t, collect(iter.SlidingBy(seq(1, 2, 3, 4, 5), 1, 2)), qt.DeepEquals, [][]int{{1}, {3}, {5}})
+}
+
+func TestPairwise(t *testing.T) {
+	x := collect(iter.Pairwise(seq(1, 2, 3)))
+	qt.Assert(t, x, qt.CmpEquals(pairComparer), []tuple.Pair[int, int]{tuple.MakePair(1, 2), tuple.MakePair(2, 3)})
+	qt.Assert(t, collect(iter.Pairwise(seq(1))), qt.IsNil)
+}
+
+func TestChunkBy(t *testing.T) {
+	x := iter.ChunkBy(seq(1, 2, 4, 5, 7), func(x, y int) bool { return y == x+1 })
+	qt.Assert(t, collect(x), qt.DeepEquals, [][]int{{1, 2}, {4, 5}, {7}})
+	qt.Assert(t, collect(iter.Take(x, 1)), qt.DeepEquals, [][]int{{1, 2}})
+}
+
+func TestGroupAdjacent(t *testing.T) {
+	x := collect(iter.GroupAdjacent(seq(1, 3, 2, 4, 5), func(x int) bool { return x%2 == 0 }))
+	qt.Assert(t, len(x), qt.Equals, 3)
+	qt.Assert(t, x[0].First(), qt.IsFalse)
+	qt.Assert(t, x[0].Second(), qt.DeepEquals, []int{1, 3})
+	qt.Assert(t, x[1].First(), qt.IsTrue)
+	qt.Assert(t, x[1].Second(), qt.DeepEquals, []int{2, 4})
+	qt.Assert(t, x[2].Second(), qt.DeepEquals, []int{5})
+}
@@ -0,0 +1,16 @@
+package lazy
+
+import (
+	"context"
+	"time"
+)
+
+// detachedContext carries the values of its parent but is never canceled,
+// so work shared by many callers isn't canceled by the one which started it.
+type detachedContext struct{ parent context.Context }
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key any) any         { return c.parent.Value(key) }
+func detach(ctx context.Context) context.Context    { return detachedContext{parent: ctx} }
@@ -0,0 +1,177 @@
+package lazy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-board/std/lazy/singleflight"
+)
+
+type memoEntry[V any] struct {
+	value   V
+	expires time.Time
+}
+
+// memoGen tells whether an invalidation happened since a computation started.
+type memoGen struct {
+	all uint64 // bumped by invalidating all keys
+	key uint64 // bumped by invalidating the key
+}
+
+// memo caches values by key, concurrent computations of the same key are collapsed.
+type memo[K comparable, V any] struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[K]memoEntry[V]
+	// gens are bumped on invalidation, so results computed before it are not cached.
+	all    uint64
+	gens   map[K]uint64
+	flying map[K]int // number of computations running per key
+	group  singleflight.Group[K, V]
+}
+
+func newMemo[K comparable, V any](ttl time.Duration) *memo[K, V] {
+	return &memo[K, V]{
+		ttl:     ttl,
+		entries: make(map[K]memoEntry[V]),
+		gens:    make(map[K]uint64),
+		flying:  make(map[K]int),
+	}
+}
+
+func (m *memo[K, V]) lookup(key K) (V, bool, memoGen) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if ok && m.ttl > 0 && !time.Now().Before(e.expires) {
+		delete(m.entries, key)
+		ok = false
+	}
+	return e.value, ok, memoGen{all: m.all, key: m.gens[key]}
+}
+
+// compute wraps f to cache its value if it succeeds and no invalidation happened since gen.
+func (m *memo[K, V]) compute(key K, gen memoGen, f func() (V, error)) func() (V, error) {
+	return func() (V, error) {
+		m.mu.Lock()
+		m.flying[key]++
+		m.mu.Unlock()
+		defer func() {
+			m.mu.Lock()
+			if m.flying[key]--; m.flying[key] == 0 {
+				delete(m.flying, key)
+			}
+			m.mu.Unlock()
+		}()
+		v, err := f()
+		if err != nil {
+			return v, err
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if gen == (memoGen{all: m.all, key: m.gens[key]}) {
+			e := memoEntry[V]{value: v}
+			if m.ttl > 0 {
+				e.expires = time.Now().Add(m.ttl)
+			}
+			m.entries[key] = e
+		}
+		return v, nil
+	}
+}
+
+func (m *memo[K, V]) invalidate(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gens[key]++
+	delete(m.entries, key)
+	m.group.Forget(key)
+}
+
+func (m *memo[K, V]) invalidateAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.all++
+	// per key generations are superseded by the new one of all keys.
+	m.gens = make(map[K]uint64)
+	m.entries = make(map[K]memoEntry[V])
+	for key := range m.flying {
+		m.group.Forget(key)
+	}
+}
+
+// Memo is a memoized func(K) V, see [Memoize].
+type Memo[K comparable, V any] struct {
+	f func(K) V
+	m *memo[K, V]
+}
+
+// Memoize wraps f to cache its value by key, for ttl or forever if ttl is zero.
+//
+// Concurrent calls for the same uncached key run f only once.
+// If f panics, the panic is re-raised in every caller and nothing is cached.
+//
+// Example:
+//
+//	fib := lazy.Memoize(slowFib, 0)
+//	fib.Get(40) // computed
+//	fib.Get(40) // cached
+func Memoize[K comparable, V any](f func(K) V, ttl time.Duration) *Memo[K, V] {
+	return &Memo[K, V]{f: f, m: newMemo[K, V](ttl)}
+}
+
+// Get returns the cached value of key, or computes and caches it.
+func (self *Memo[K, V]) Get(key K) V {
+	v, ok, gen := self.m.lookup(key)
+	if ok {
+		return v
+	}
+	v, _, _ = self.m.group.Do(key, self.m.compute(key, gen, func() (V, error) { return self.f(key), nil }))
+	return v
+}
+
+// Invalidate drops the cached value of key.
+func (self *Memo[K, V]) Invalidate(key K) { self.m.invalidate(key) }
+
+// InvalidateAll drops all cached values.
+func (self *Memo[K, V]) InvalidateAll() { self.m.invalidateAll() }
+
+// MemoCtx is a memoized func(context.Context, K) (V, error), see [MemoizeCtx].
+type MemoCtx[K comparable, V any] struct {
+	f func(context.Context, K) (V, error)
+	m *memo[K, V]
+}
+
+// MemoizeCtx wraps f to cache its successful value by key, for ttl or forever if ttl is zero.
+// Errors are returned to callers but never cached, the next call tries again.
+//
+// Concurrent calls for the same uncached key share a single call of f,
+// which runs with the values of the context of the caller starting it,
+// but isn't canceled with it. Every caller stops waiting once its own context is done,
+// the shared call keeps running and caches its value for later calls.
+func MemoizeCtx[K comparable, V any](f func(context.Context, K) (V, error), ttl time.Duration) *MemoCtx[K, V] {
+	return &MemoCtx[K, V]{f: f, m: newMemo[K, V](ttl)}
+}
+
+// Get returns the cached value of key, or computes and caches it.
+func (self *MemoCtx[K, V]) Get(ctx context.Context, key K) (V, error) {
+	v, ok, gen := self.m.lookup(key)
+	if ok {
+		return v, nil
+	}
+	ch := self.m.group.DoChan(key, self.m.compute(key, gen, func() (V, error) { return self.f(detach(ctx), key) }))
+	select {
+	case r := <-ch:
+		return r.Val, r.Err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// Invalidate drops the cached value of key.
+func (self *MemoCtx[K, V]) Invalidate(key K) { self.m.invalidate(key) }
+
+// InvalidateAll drops all cached values.
+func (self *MemoCtx[K, V]) InvalidateAll() { self.m.invalidateAll() }
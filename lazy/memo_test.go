@@ -0,0 +1,154 @@
+package lazy_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/go-board/std/lazy"
+)
+
+func TestMemoize(t *testing.T) {
+	var calls atomic.Int32
+	square := lazy.Memoize(func(x int) int { calls.Add(1); return x * x }, 0)
+	quicktest.Assert(t, square.Get(3), quicktest.Equals, 9)
+	quicktest.Assert(t, square.Get(3), quicktest.Equals, 9)
+	quicktest.Assert(t, square.Get(4), quicktest.Equals, 16)
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(2))
+
+	square.Invalidate(3)
+	quicktest.Assert(t, square.Get(3), quicktest.Equals, 9)
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(3))
+	square.InvalidateAll()
+	square.Get(3)
+	square.Get(4)
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(5))
+}
+
+func TestMemoize_Concurrent(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	m := lazy.Memoize(func(x int) int { calls.Add(1); <-release; return x }, 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() { defer wg.Done(); quicktest.Check(t, m.Get(1), quicktest.Equals, 1) }()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(1))
+}
+
+func TestMemoize_TTL(t *testing.T) {
+	var calls atomic.Int32
+	m := lazy.Memoize(func(x int) int { return int(calls.Add(1)) }, 20*time.Millisecond)
+	quicktest.Assert(t, m.Get(0), quicktest.Equals, 1)
+	quicktest.Assert(t, m.Get(0), quicktest.Equals, 1)
+	time.Sleep(30 * time.Millisecond)
+	quicktest.Assert(t, m.Get(0), quicktest.Equals, 2)
+}
+
+func TestMemoizeCtx(t *testing.T) {
+	boom := errors.New("boom")
+	var calls atomic.Int32
+	fail := true
+	m := lazy.MemoizeCtx(func(ctx context.Context, k string) (int, error) {
+		calls.Add(1)
+		if fail {
+			return 0, boom
+		}
+		return len(k), nil
+	}, 0)
+	ctx := context.Background()
+	_, err := m.Get(ctx, "abc")
+	quicktest.Assert(t, err, quicktest.Equals, boom)
+	fail = false
+	v, err := m.Get(ctx, "abc")
+	quicktest.Assert(t, err, quicktest.IsNil)
+	quicktest.Assert(t, v, quicktest.Equals, 3)
+	v, _ = m.Get(ctx, "abc")
+	quicktest.Assert(t, v, quicktest.Equals, 3)
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(2))
+}
+
+func TestMemoizeCtx_Cancel(t *testing.T) {
+	release := make(chan struct{})
+	m := lazy.MemoizeCtx(func(ctx context.Context, k int) (int, error) { <-release; return k, nil }, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := m.Get(ctx, 1)
+	quicktest.Assert(t, err, quicktest.Equals, context.DeadlineExceeded)
+
+	m.Invalidate(1)
+	close(release)
+	v, err := m.Get(context.Background(), 1)
+	quicktest.Assert(t, err, quicktest.IsNil)
+	quicktest.Assert(t, v, quicktest.Equals, 1)
+}
+
+func TestMemoizeCtx_LeaderCancel(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	m := lazy.MemoizeCtx(func(ctx context.Context, k int) (int, error) {
+		close(started)
+		<-release
+		return k, ctx.Err()
+	}, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() { _, err := m.Get(ctx, 1); leader <- err }()
+	<-started
+	follower := make(chan int)
+	go func() { v, err := m.Get(context.Background(), 1); quicktest.Check(t, err, quicktest.IsNil); follower <- v }()
+	cancel()
+	quicktest.Assert(t, <-leader, quicktest.Equals, context.Canceled)
+	close(release)
+	quicktest.Assert(t, <-follower, quicktest.Equals, 1)
+}
+
+func TestMemoizeCtx_InvalidateInFlight(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	m := lazy.MemoizeCtx(func(ctx context.Context, k int) (int, error) {
+		calls.Add(1)
+		if k == 1 {
+			<-release
+		}
+		return k, nil
+	}, 0)
+
+	done := make(chan struct{})
+	go func() { defer close(done); m.Get(context.Background(), 1) }()
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// invalidating another key doesn't stop key 1 from being cached.
+	m.Invalidate(2)
+	close(release)
+	<-done
+	m.Get(context.Background(), 1)
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(1))
+
+	// invalidating all keys forgets in-flight computations.
+	release = make(chan struct{})
+	m.InvalidateAll()
+	go func() { m.Get(context.Background(), 1) }()
+	for calls.Load() == 1 {
+		time.Sleep(time.Millisecond)
+	}
+	m.InvalidateAll()
+	v, err := m.Get(context.Background(), 3)
+	quicktest.Assert(t, err, quicktest.IsNil)
+	quicktest.Assert(t, v, quicktest.Equals, 3)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	m.Get(ctx, 1)
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(4))
+	close(release)
+}
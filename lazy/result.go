@@ -0,0 +1,156 @@
+package lazy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-board/std/optional"
+	"github.com/go-board/std/result"
+)
+
+// LazyResult is a lazy value whose initialization may fail.
+//
+// A successful value is computed once and kept until [LazyResult.Reset],
+// a failure is not kept, the next [LazyResult.Get] tries again once the backoff has passed.
+// It's safe for concurrent use, concurrent callers share a single initialization.
+type LazyResult[T any] struct {
+	init    func(ctx context.Context) result.Result[T]
+	backoff func(failures int) time.Duration
+
+	mu       sync.Mutex
+	last     optional.Optional[result.Result[T]]
+	failures int
+	retryAt  time.Time
+	// gen is bumped by Reset, so an initialization started before it is discarded.
+	gen uint64
+	// loading is non-nil while an initialization is in progress.
+	loading *lazyLoad[T]
+}
+
+// NewLazyResult creates a [LazyResult] which is initialized by f on the first [LazyResult.Get].
+func NewLazyResult[T any](f func(ctx context.Context) result.Result[T]) *LazyResult[T] {
+	return &LazyResult[T]{init: f}
+}
+
+// WithBackoff sets how long to wait before retrying after the given number of consecutive failures,
+// during which [LazyResult.Get] returns the last failure without retrying.
+//
+// By default it retries immediately. It must be called before the first [LazyResult.Get].
+func (self *LazyResult[T]) WithBackoff(f func(failures int) time.Duration) *LazyResult[T] {
+	self.backoff = f
+	return self
+}
+
+// ExponentialBackoff returns a backoff function for [LazyResult.WithBackoff],
+// waiting base after the first failure, doubling on each failure and capped at max.
+func ExponentialBackoff(base, max time.Duration) func(failures int) time.Duration {
+	return func(failures int) time.Duration {
+		d := base
+		for i := 1; i < failures && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		return d
+	}
+}
+
+// Get returns the value, initializing it if it's not initialized yet.
+//
+// The initialization runs on its own goroutine with the values of the ctx of the caller
+// starting it, but isn't canceled with it, so one caller giving up doesn't fail the others.
+// Every caller, the starting one included, stops waiting with its ctx error once its own ctx is done.
+// If the initialization panics, the panic is re-raised in the caller starting it
+// and recorded as an error for the others.
+func (self *LazyResult[T]) Get(ctx context.Context) result.Result[T] {
+	for {
+		self.mu.Lock()
+		if self.last.IsSome() {
+			last := self.last.Value()
+			if last.IsOk() || time.Now().Before(self.retryAt) {
+				self.mu.Unlock()
+				return last
+			}
+		}
+		load, started := self.loading, false
+		if load == nil {
+			load = &lazyLoad[T]{done: make(chan struct{})}
+			self.loading, started = load, true
+			go self.initialize(detach(ctx), load, self.gen)
+		}
+		self.mu.Unlock()
+		select {
+		case <-load.done:
+		case <-ctx.Done():
+			return result.Err[T](ctx.Err())
+		}
+		if load.stale {
+			continue
+		}
+		if started && load.panic != nil {
+			panic(load.panic)
+		}
+		return load.res
+	}
+}
+
+// lazyLoad is an initialization in progress, its fields are set before done is closed.
+type lazyLoad[T any] struct {
+	done  chan struct{}
+	res   result.Result[T]
+	panic any
+	// stale is set if Reset happened meanwhile, so waiters start a new initialization.
+	stale bool
+}
+
+func (self *LazyResult[T]) initialize(ctx context.Context, load *lazyLoad[T], gen uint64) {
+	defer func() {
+		if p := recover(); p != nil {
+			load.panic = p
+			load.res = result.Err[T](fmt.Errorf("lazy: initialization panicked: %v", p))
+		}
+		self.mu.Lock()
+		defer self.mu.Unlock()
+		// a result of an initialization started before Reset is discarded.
+		if self.gen == gen {
+			self.last = optional.Some(load.res)
+			if load.res.IsOk() {
+				self.failures = 0
+			} else {
+				self.failures++
+				if self.backoff != nil {
+					self.retryAt = time.Now().Add(self.backoff(self.failures))
+				}
+			}
+		} else {
+			load.stale = true
+		}
+		self.loading = nil
+		close(load.done)
+	}()
+	load.res = self.init(ctx)
+}
+
+// Peek returns the last completed result without initializing,
+// or None if no initialization has completed since creation or [LazyResult.Reset].
+func (self *LazyResult[T]) Peek() optional.Optional[result.Result[T]] {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.last
+}
+
+// Reset drops the value and failure history, so the next [LazyResult.Get] initializes again.
+//
+// An initialization in progress is not interrupted, but its result is discarded,
+// callers waiting for it start a new one.
+func (self *LazyResult[T]) Reset() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.gen++
+	self.last = optional.None[result.Result[T]]()
+	self.failures = 0
+	self.retryAt = time.Time{}
+}
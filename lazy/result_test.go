@@ -0,0 +1,129 @@
+package lazy_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/frankban/quicktest"
+
+	"github.com/go-board/std/lazy"
+	"github.com/go-board/std/result"
+)
+
+var errInit = errors.New("init failed")
+
+func TestLazyResult(t *testing.T) {
+	var calls atomic.Int32
+	l := lazy.NewLazyResult(func(ctx context.Context) result.Result[int] {
+		if calls.Add(1) < 3 {
+			return result.Err[int](errInit)
+		}
+		return result.Ok(42)
+	})
+	ctx := context.Background()
+	quicktest.Assert(t, l.Peek().IsNone(), quicktest.IsTrue)
+	quicktest.Assert(t, l.Get(ctx).Error(), quicktest.Equals, errInit)
+	quicktest.Assert(t, l.Peek().Value().IsErr(), quicktest.IsTrue)
+	quicktest.Assert(t, l.Get(ctx).Error(), quicktest.Equals, errInit)
+	quicktest.Assert(t, l.Get(ctx).Value(), quicktest.Equals, 42)
+	quicktest.Assert(t, l.Get(ctx).Value(), quicktest.Equals, 42)
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(3))
+
+	l.Reset()
+	quicktest.Assert(t, l.Peek().IsNone(), quicktest.IsTrue)
+	quicktest.Assert(t, l.Get(ctx).Value(), quicktest.Equals, 42)
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(4))
+}
+
+func TestLazyResult_Backoff(t *testing.T) {
+	var calls atomic.Int32
+	l := lazy.NewLazyResult(func(ctx context.Context) result.Result[int] {
+		calls.Add(1)
+		return result.Err[int](errInit)
+	}).WithBackoff(func(int) time.Duration { return 30 * time.Millisecond })
+	ctx := context.Background()
+	l.Get(ctx)
+	l.Get(ctx)
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(1))
+	time.Sleep(40 * time.Millisecond)
+	quicktest.Assert(t, l.Get(ctx).IsErr(), quicktest.IsTrue)
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(2))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := lazy.ExponentialBackoff(time.Second, 5*time.Second)
+	quicktest.Assert(t, b(1), quicktest.Equals, time.Second)
+	quicktest.Assert(t, b(2), quicktest.Equals, 2*time.Second)
+	quicktest.Assert(t, b(3), quicktest.Equals, 4*time.Second)
+	quicktest.Assert(t, b(4), quicktest.Equals, 5*time.Second)
+	quicktest.Assert(t, b(100), quicktest.Equals, 5*time.Second)
+}
+
+func TestLazyResult_Concurrent(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	l := lazy.NewLazyResult(func(ctx context.Context) result.Result[string] {
+		calls.Add(1)
+		<-release
+		return result.Ok("ready")
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			quicktest.Check(t, l.Get(context.Background()).Value(), quicktest.Equals, "ready")
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	quicktest.Assert(t, l.Get(ctx).Error(), quicktest.Equals, context.DeadlineExceeded)
+	close(release)
+	wg.Wait()
+	quicktest.Assert(t, calls.Load(), quicktest.Equals, int32(1))
+}
+
+func TestLazyResult_Panic(t *testing.T) {
+	first := true
+	l := lazy.NewLazyResult(func(ctx context.Context) result.Result[int] {
+		if first {
+			first = false
+			panic("oops")
+		}
+		return result.Ok(1)
+	})
+	quicktest.Assert(t, func() { l.Get(context.Background()) }, quicktest.PanicMatches, "oops")
+	quicktest.Assert(t, l.Peek().Value().IsErr(), quicktest.IsTrue)
+	quicktest.Assert(t, l.Get(context.Background()).Value(), quicktest.Equals, 1)
+}
+
+func TestLazyResult_InitiatorCancel(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	l := lazy.NewLazyResult(func(ctx context.Context) result.Result[int] {
+		close(started)
+		<-release
+		if err := ctx.Err(); err != nil {
+			return result.Err[int](err)
+		}
+		return result.Ok(1)
+	}).WithBackoff(func(int) time.Duration { return time.Hour })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	initiator := make(chan result.Result[int])
+	go func() { initiator <- l.Get(ctx) }()
+	<-started
+	waiter := make(chan result.Result[int])
+	go func() { waiter <- l.Get(context.Background()) }()
+	cancel()
+	// the initiator stops waiting at once, while the initialization goes on.
+	quicktest.Assert(t, (<-initiator).Error(), quicktest.Equals, context.Canceled)
+	close(release)
+	quicktest.Assert(t, (<-waiter).Value(), quicktest.Equals, 1)
+	quicktest.Assert(t, l.Get(context.Background()).Value(), quicktest.Equals, 1)
+}
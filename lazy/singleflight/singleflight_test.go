@@ -0,0 +1,86 @@
+package singleflight_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/lazy/singleflight"
+)
+
+func TestDo(t *testing.T) {
+	var g singleflight.Group[string, int]
+	v, err, shared := g.Do("key", func() (int, error) { return 42, nil })
+	qt.Assert(t, v, qt.Equals, 42)
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, shared, qt.IsFalse)
+
+	boom := errors.New("boom")
+	_, err, _ = g.Do("key", func() (int, error) { return 0, boom })
+	qt.Assert(t, err, qt.Equals, boom)
+}
+
+func TestDoDupSuppress(t *testing.T) {
+	var g singleflight.Group[int, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 1, nil
+	}
+	const n = 10
+	var wg sync.WaitGroup
+	var sharedCount atomic.Int32
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err, shared := g.Do(0, fn)
+			qt.Check(t, v, qt.Equals, 1)
+			qt.Check(t, err, qt.IsNil)
+			if shared {
+				sharedCount.Add(1)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	qt.Assert(t, calls.Load(), qt.Equals, int32(1))
+	qt.Assert(t, sharedCount.Load(), qt.Equals, int32(n))
+}
+
+func TestDoChan(t *testing.T) {
+	var g singleflight.Group[string, string]
+	r := <-g.DoChan("a", func() (string, error) { return "x", nil })
+	qt.Assert(t, r.Val, qt.Equals, "x")
+	qt.Assert(t, r.Err, qt.IsNil)
+
+	r = <-g.DoChan("a", func() (string, error) { panic("oops") })
+	var pe *singleflight.PanicError
+	qt.Assert(t, errors.As(r.Err, &pe), qt.IsTrue)
+	qt.Assert(t, pe.Value, qt.Equals, "oops")
+}
+
+func TestDoPanic(t *testing.T) {
+	var g singleflight.Group[int, int]
+	qt.Assert(t, func() { g.Do(1, func() (int, error) { panic("oops") }) }, qt.PanicMatches, "oops")
+	v, _, _ := g.Do(1, func() (int, error) { return 1, nil })
+	qt.Assert(t, v, qt.Equals, 1)
+}
+
+func TestForget(t *testing.T) {
+	var g singleflight.Group[int, int]
+	release := make(chan struct{})
+	first := g.DoChan(1, func() (int, error) { <-release; return 1, nil })
+	g.Forget(1)
+	v, _, shared := g.Do(1, func() (int, error) { return 2, nil })
+	qt.Assert(t, v, qt.Equals, 2)
+	qt.Assert(t, shared, qt.IsFalse)
+	close(release)
+	qt.Assert(t, (<-first).Val, qt.Equals, 1)
+}
@@ -27,6 +27,19 @@ func Entries[K comparable, V any, M ~map[K]V](m M) iter.Seq[MapEntry[K, V]] {
 	}
 }
 
+// Iter returns all k-v pairs of a map as an [iter.Seq2].
+//
+// The pair order is unordered.
+func Iter[K comparable, V any, M ~map[K]V](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				break
+			}
+		}
+	}
+}
+
 // EntrySlice return entry slice of a map.
 func EntrySlice[K comparable, V any, M ~map[K]V](m M) []MapEntry[K, V] {
 	return collector.Collect(Entries(m), collector.ToSlice[MapEntry[K, V]]())
@@ -70,6 +83,13 @@ func Collect[K comparable, V any](s iter.Seq[MapEntry[K, V]]) map[K]V {
 	return collector.Collect(s, collector.ToMap(extract))
 }
 
+// CollectKV collects [iter.Seq2] into a map.
+func CollectKV[K comparable, V any](s iter.Seq2[K, V]) map[K]V {
+	m := make(map[K]V)
+	s(func(k K, v V) bool { m[k] = v; return true })
+	return m
+}
+
 func CollectMap[K comparable, V any, E any](s iter.Seq[E], f func(E) (K, V)) map[K]V {
 	return collector.Collect(s, collector.ToMap(f))
 }
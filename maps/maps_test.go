@@ -0,0 +1,17 @@
+package maps_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/maps"
+)
+
+func TestIter(t *testing.T) {
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	qt.Assert(t, maps.CollectKV(maps.Iter(m)), qt.DeepEquals, m)
+
+	n := 0
+	maps.Iter(m)(func(string, int) bool { n++; return false })
+	qt.Assert(t, n, qt.Equals, 1)
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/go-board/std/hash"
+	"github.com/go-board/std/result"
+)
+
+// ErrNoInstances is returned by balancers when discovery has no instance.
+var ErrNoInstances = errors.New("service: no instances")
+
+type derivedValue[T any] struct {
+	version uint64
+	val     T
+}
+
+// derived caches a value computed from the instances of a [Discover] until they change.
+type derived[Req, Resp, T any] struct {
+	d       Discover[Req, Resp]
+	compute func(instances []Instance[Req, Resp], prev T) T
+	cur     atomic.Pointer[derivedValue[T]]
+}
+
+// get returns the instances with the value derived from them,
+// racing callers may compute it more than once, but all of them get a consistent pair.
+func (self *derived[Req, Resp, T]) get() ([]Instance[Req, Resp], T) {
+	instances, version := self.d.Instances()
+	cur := self.cur.Load()
+	if cur != nil && cur.version == version {
+		return instances, cur.val
+	}
+	var prev T
+	if cur != nil {
+		prev = cur.val
+	}
+	next := &derivedValue[T]{version: version, val: self.compute(instances, prev)}
+	self.cur.Store(next)
+	return instances, next.val
+}
+
+func noInstances[Resp any]() result.Result[Resp] { return result.Err[Resp](ErrNoInstances) }
+
+// RoundRobin dispatches calls to the instances of d in turn.
+func RoundRobin[Req, Resp any](d Discover[Req, Resp]) Service[Req, Resp] {
+	var next atomic.Uint64
+	return ServiceFn[Req, Resp](func(ctx context.Context, req Req) result.Result[Resp] {
+		instances, _ := d.Instances()
+		if len(instances) == 0 {
+			return noInstances[Resp]()
+		}
+		i := (next.Add(1) - 1) % uint64(len(instances))
+		return instances[i].Service.Call(ctx, req)
+	})
+}
+
+// Random dispatches each call to a random instance of d.
+func Random[Req, Resp any](d Discover[Req, Resp]) Service[Req, Resp] {
+	return ServiceFn[Req, Resp](func(ctx context.Context, req Req) result.Result[Resp] {
+		instances, _ := d.Instances()
+		if len(instances) == 0 {
+			return noInstances[Resp]()
+		}
+		return instances[rand.Intn(len(instances))].Service.Call(ctx, req)
+	})
+}
+
+// P2C dispatches each call by the power of two choices,
+// it picks two random instances of d and calls the one with fewer calls in flight.
+//
+// Loads are tracked by instance key, so they survive changes of the instance set.
+func P2C[Req, Resp any](d Discover[Req, Resp]) Service[Req, Resp] {
+	loads := &derived[Req, Resp, map[string]*atomic.Int64]{
+		d: d,
+		compute: func(instances []Instance[Req, Resp], prev map[string]*atomic.Int64) map[string]*atomic.Int64 {
+			m := make(map[string]*atomic.Int64, len(instances))
+			for _, i := range instances {
+				if load, ok := prev[i.Key]; ok {
+					m[i.Key] = load
+				} else {
+					m[i.Key] = &atomic.Int64{}
+				}
+			}
+			return m
+		},
+	}
+	return ServiceFn[Req, Resp](func(ctx context.Context, req Req) result.Result[Resp] {
+		instances, m := loads.get()
+		if len(instances) == 0 {
+			return noInstances[Resp]()
+		}
+		pick := instances[0]
+		if n := len(instances); n > 1 {
+			a := rand.Intn(n)
+			b := rand.Intn(n - 1)
+			if b >= a {
+				b++
+			}
+			pick = instances[a]
+			if m[instances[b].Key].Load() < m[pick.Key].Load() {
+				pick = instances[b]
+			}
+		}
+		load := m[pick.Key]
+		load.Add(1)
+		defer load.Add(-1)
+		return pick.Service.Call(ctx, req)
+	})
+}
+
+type ringPoint struct {
+	hash  uint64
+	index int
+}
+
+// mix spreads the bits of a hash, so close inputs land far apart on the ring.
+func mix(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	return h
+}
+
+// ConsistentHash dispatches calls by the hash of their request on a consistent hash ring,
+// so requests of the same hash keep going to the same instance, and a change of the instances
+// only moves the requests of the instances added or removed.
+//
+// Each instance is placed on the ring replicas times by hashing its key with the hash package,
+// hashOf hashes a request, e.g. by [hash.Hash] of its routing key.
+func ConsistentHash[Req, Resp any](d Discover[Req, Resp], replicas int, hashOf func(Req) uint64) Service[Req, Resp] {
+	if replicas < 1 {
+		replicas = 1
+	}
+	ring := &derived[Req, Resp, []ringPoint]{
+		d: d,
+		compute: func(instances []Instance[Req, Resp], _ []ringPoint) []ringPoint {
+			points := make([]ringPoint, 0, len(instances)*replicas)
+			for i, inst := range instances {
+				for r := 0; r < replicas; r++ {
+					points = append(points, ringPoint{hash: mix(hash.BytesLike(inst.Key + "#" + strconv.Itoa(r))), index: i})
+				}
+			}
+			sort.Slice(points, func(i, j int) bool { return points[i].hash < points[j].hash })
+			return points
+		},
+	}
+	return ServiceFn[Req, Resp](func(ctx context.Context, req Req) result.Result[Resp] {
+		instances, points := ring.get()
+		if len(instances) == 0 {
+			return noInstances[Resp]()
+		}
+		h := mix(hashOf(req))
+		i := sort.Search(len(points), func(i int) bool { return points[i].hash >= h })
+		if i == len(points) {
+			i = 0
+		}
+		return instances[points[i].index].Service.Call(ctx, req)
+	})
+}
@@ -0,0 +1,156 @@
+package service_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/hash"
+	"github.com/go-board/std/result"
+	"github.com/go-board/std/service"
+)
+
+// named returns an instance answering with its key.
+func named(key string) service.Instance[int, string] {
+	return service.Instance[int, string]{Key: key, Service: service.ServiceFn[int, string](func(ctx context.Context, req int) result.Result[string] {
+		return result.Ok(key)
+	})}
+}
+
+func TestStaticDiscover(t *testing.T) {
+	var d service.StaticDiscover[int, string]
+	instances, v0 := d.Instances()
+	qt.Assert(t, instances, qt.HasLen, 0)
+	d.Add(named("a"))
+	d.Add(named("b"))
+	d.Add(named("a"))
+	instances, v1 := d.Instances()
+	qt.Assert(t, v1 > v0, qt.IsTrue)
+	qt.Assert(t, instances, qt.HasLen, 2)
+	qt.Assert(t, instances[0].Key, qt.Equals, "b")
+	d.Remove("b")
+	instances, _ = d.Instances()
+	qt.Assert(t, instances, qt.HasLen, 1)
+	qt.Assert(t, instances[0].Key, qt.Equals, "a")
+}
+
+func TestBalancers_NoInstances(t *testing.T) {
+	d := service.NewStaticDiscover[int, string]()
+	for _, s := range []service.Service[int, string]{
+		service.RoundRobin[int, string](d),
+		service.Random[int, string](d),
+		service.P2C[int, string](d),
+		service.ConsistentHash[int, string](d, 10, func(req int) uint64 { return hash.Int64(int64(req)) }),
+	} {
+		qt.Assert(t, s.Call(context.Background(), 0).Error(), qt.Equals, service.ErrNoInstances)
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	s := service.RoundRobin[int, string](service.NewStaticDiscover(named("a"), named("b"), named("c")))
+	var got []string
+	for i := 0; i < 6; i++ {
+		got = append(got, s.Call(context.Background(), i).Value())
+	}
+	qt.Assert(t, got, qt.DeepEquals, []string{"a", "b", "c", "a", "b", "c"})
+}
+
+func TestRandom(t *testing.T) {
+	s := service.Random[int, string](service.NewStaticDiscover(named("a"), named("b")))
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		seen[s.Call(context.Background(), i).Value()] = true
+	}
+	qt.Assert(t, seen, qt.DeepEquals, map[string]bool{"a": true, "b": true})
+}
+
+func TestP2C(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	slow := service.Instance[int, string]{Key: "slow", Service: service.ServiceFn[int, string](func(ctx context.Context, req int) result.Result[string] {
+		started <- struct{}{}
+		<-release
+		return result.Ok("slow")
+	})}
+	s := service.P2C[int, string](service.NewStaticDiscover(slow, named("fast")))
+
+	// keep calling until the slow instance is busy, then the fast one takes all calls.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			if s.Call(context.Background(), 0).Value() == "slow" {
+				return
+			}
+		}
+	}()
+	<-started
+	for i := 0; i < 20; i++ {
+		qt.Assert(t, s.Call(context.Background(), i).Value(), qt.Equals, "fast")
+	}
+	close(release)
+	wg.Wait()
+}
+
+func TestConsistentHash(t *testing.T) {
+	d := service.NewStaticDiscover(named("a"), named("b"), named("c"))
+	s := service.ConsistentHash[int, string](d, 50, func(req int) uint64 { return hash.Int64(int64(req)) })
+	before := map[int]string{}
+	counts := map[string]int{}
+	for i := 0; i < 300; i++ {
+		before[i] = s.Call(context.Background(), i).Value()
+		counts[before[i]]++
+		qt.Assert(t, s.Call(context.Background(), i).Value(), qt.Equals, before[i])
+	}
+	qt.Assert(t, counts, qt.HasLen, 3)
+
+	d.Remove("c")
+	for i := 0; i < 300; i++ {
+		got := s.Call(context.Background(), i).Value()
+		if before[i] != "c" {
+			qt.Assert(t, got, qt.Equals, before[i], qt.Commentf("request %d moved", i))
+		} else {
+			qt.Assert(t, got, qt.Not(qt.Equals), "c")
+		}
+	}
+}
+
+func TestRouter(t *testing.T) {
+	even := named("even").Service
+	router := service.NewRouter[int, string]().
+		Route(func(req int) bool { return req%2 == 0 }, even)
+	qt.Assert(t, router.Call(context.Background(), 2).Value(), qt.Equals, "even")
+	qt.Assert(t, router.Call(context.Background(), 1).Error(), qt.Equals, service.ErrNoRoute)
+	router.Default(named("odd").Service)
+	qt.Assert(t, router.Call(context.Background(), 1).Value(), qt.Equals, "odd")
+}
+
+func TestRouteByKey(t *testing.T) {
+	s := service.RouteByKey(strconv.Itoa, map[string]service.Service[int, string]{
+		"1": named("one").Service,
+	}, nil)
+	qt.Assert(t, s.Call(context.Background(), 1).Value(), qt.Equals, "one")
+	qt.Assert(t, s.Call(context.Background(), 2).Error(), qt.Equals, service.ErrNoRoute)
+}
+
+func TestSteer(t *testing.T) {
+	s := service.Steer(func(req int, n int) int { return req % n }, named("a").Service, named("b").Service)
+	qt.Assert(t, s.Call(context.Background(), 3).Value(), qt.Equals, "b")
+	s = service.Steer(func(req int, n int) int { return -1 }, named("a").Service)
+	qt.Assert(t, s.Call(context.Background(), 3).Error(), qt.Equals, service.ErrNoRoute)
+}
+
+func TestFallback(t *testing.T) {
+	failing := service.ServiceFn[int, string](func(ctx context.Context, req int) result.Result[string] {
+		return result.Err[string](errBackend)
+	})
+	s := service.Fallback[int, string](failing, named("backup").Service, nil)
+	qt.Assert(t, s.Call(context.Background(), 0).Value(), qt.Equals, "backup")
+	s = service.Fallback[int, string](failing, named("backup").Service, func(err error) bool { return false })
+	qt.Assert(t, s.Call(context.Background(), 0).Error(), qt.Equals, errBackend)
+	s = service.Fallback[int, string](named("primary").Service, named("backup").Service, nil)
+	qt.Assert(t, s.Call(context.Background(), 0).Value(), qt.Equals, "primary")
+}
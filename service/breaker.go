@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-board/std/result"
+)
+
+// BreakerState is the state of a [CircuitBreaker].
+type BreakerState int
+
+const (
+	// StateClosed lets all calls through, counting consecutive failures.
+	StateClosed BreakerState = iota
+	// StateOpen rejects all calls until OpenTimeout has passed.
+	StateOpen
+	// StateHalfOpen lets a few trial calls through to decide whether to close or open again.
+	StateHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// BreakerConfig configures a [CircuitBreaker], zero fields take their defaults.
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures opening the circuit, defaults to 5.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before trial calls, defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenCalls is the number of trial calls let through when half-open,
+	// the circuit closes once all of them succeed and opens again on any failure. Defaults to 1.
+	HalfOpenCalls int
+	// IsFailure reports whether err counts as a failure, all errors do if nil.
+	IsFailure func(err error) bool
+	// OnStateChange is called on every state transition, while holding the lock of the breaker.
+	OnStateChange func(from, to BreakerState)
+	// Clock tells when the open timeout has passed, defaults to [SystemClock].
+	Clock Clock
+}
+
+// CircuitBreaker stops calling a failing service for a while, to let it recover
+// and to fail fast meanwhile. It's applied to services by [Breaker].
+type CircuitBreaker struct {
+	cfg BreakerConfig
+
+	mu       sync.Mutex
+	state    BreakerState
+	gen      uint64 // bumped on every transition, so results of calls from a previous state are ignored
+	failures int
+	openedAt time.Time
+	trials   int
+	passed   int
+}
+
+// NewCircuitBreaker creates a closed [CircuitBreaker].
+func NewCircuitBreaker(cfg BreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold < 1 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	if cfg.HalfOpenCalls < 1 {
+		cfg.HalfOpenCalls = 1
+	}
+	cfg.Clock = clockOrSystem(cfg.Clock)
+	return &CircuitBreaker{cfg: cfg}
+}
+
+// State returns the current state.
+func (self *CircuitBreaker) State() BreakerState {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.refreshLocked()
+	return self.state
+}
+
+func (self *CircuitBreaker) transitLocked(to BreakerState) {
+	from := self.state
+	self.state = to
+	self.gen++
+	self.failures, self.trials, self.passed = 0, 0, 0
+	if to == StateOpen {
+		self.openedAt = self.cfg.Clock.Now()
+	}
+	if self.cfg.OnStateChange != nil {
+		self.cfg.OnStateChange(from, to)
+	}
+}
+
+// refreshLocked moves from open to half-open once the open timeout has passed.
+func (self *CircuitBreaker) refreshLocked() {
+	if self.state == StateOpen && self.cfg.Clock.Now().Sub(self.openedAt) >= self.cfg.OpenTimeout {
+		self.transitLocked(StateHalfOpen)
+	}
+}
+
+// acquire reports whether a call may go through, and the generation to record its outcome in.
+func (self *CircuitBreaker) acquire() (uint64, bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.refreshLocked()
+	switch self.state {
+	case StateOpen:
+		return 0, false
+	case StateHalfOpen:
+		if self.trials >= self.cfg.HalfOpenCalls {
+			return 0, false
+		}
+		self.trials++
+	}
+	return self.gen, true
+}
+
+// isFailure reports whether err counts as a failure.
+func (self *CircuitBreaker) isFailure(err error) bool {
+	return err != nil && (self.cfg.IsFailure == nil || self.cfg.IsFailure(err))
+}
+
+func (self *CircuitBreaker) record(gen uint64, failed bool) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	if gen != self.gen {
+		return
+	}
+	switch self.state {
+	case StateClosed:
+		if !failed {
+			self.failures = 0
+		} else if self.failures++; self.failures >= self.cfg.FailureThreshold {
+			self.transitLocked(StateOpen)
+		}
+	case StateHalfOpen:
+		if failed {
+			self.transitLocked(StateOpen)
+		} else if self.passed++; self.passed >= self.cfg.HalfOpenCalls {
+			self.transitLocked(StateClosed)
+		}
+	}
+}
+
+// Breaker guards calls with breaker, calls rejected by it fail with [ErrCircuitOpen].
+//
+// The breaker may be shared by several services to trip them together.
+// A call which panics counts as a failure, the panic goes on to the caller.
+func Breaker[Req, Resp any](breaker *CircuitBreaker) Layer[Service[Req, Resp]] {
+	return layer(func(s Service[Req, Resp], ctx context.Context, req Req) result.Result[Resp] {
+		gen, ok := breaker.acquire()
+		if !ok {
+			return result.Err[Resp](ErrCircuitOpen)
+		}
+		// recorded on the way out, so a panicking call doesn't hold a half-open trial forever.
+		failed := true
+		defer func() { breaker.record(gen, failed) }()
+		r := s.Call(ctx, req)
+		failed = breaker.isFailure(r.ErrorOr(nil))
+		return r
+	})
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and waits for it, layers depending on time take one so tests can fake it.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel which receives once d has passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock returns a [Clock] reading the wall clock.
+func SystemClock() Clock { return systemClock{} }
+
+func clockOrSystem(c Clock) Clock {
+	if c == nil {
+		return SystemClock()
+	}
+	return c
+}
+
+// clockContext is done once its parent is, or once its deadline passes on a [Clock],
+// so deadlines can be faked in tests like other timing of layers.
+type clockContext struct {
+	context.Context
+	deadline time.Time
+	done     chan struct{}
+	once     sync.Once
+	// err is set before done is closed.
+	err error
+}
+
+// withTimeout is like [context.WithTimeout], but measures d on clock.
+func withTimeout(parent context.Context, clock Clock, d time.Duration) (context.Context, context.CancelFunc) {
+	ctx := &clockContext{Context: parent, deadline: clock.Now().Add(d), done: make(chan struct{})}
+	stop := make(chan struct{})
+	var stopOnce sync.Once
+	go func() {
+		select {
+		case <-parent.Done():
+			ctx.cancel(parent.Err())
+		case <-clock.After(d):
+			ctx.cancel(context.DeadlineExceeded)
+		case <-stop:
+		}
+	}()
+	return ctx, func() {
+		stopOnce.Do(func() { close(stop) })
+		ctx.cancel(context.Canceled)
+	}
+}
+
+func (self *clockContext) cancel(err error) {
+	self.once.Do(func() {
+		self.err = err
+		close(self.done)
+	})
+}
+
+func (self *clockContext) Deadline() (time.Time, bool) {
+	if d, ok := self.Context.Deadline(); ok && d.Before(self.deadline) {
+		return d, true
+	}
+	return self.deadline, true
+}
+
+func (self *clockContext) Done() <-chan struct{} { return self.done }
+
+func (self *clockContext) Err() error {
+	select {
+	case <-self.done:
+		return self.err
+	default:
+		return nil
+	}
+}
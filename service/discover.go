@@ -0,0 +1,89 @@
+package service
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Instance is a service instance found by a [Discover], Key identifies it, e.g. by its address.
+type Instance[Req, Resp any] struct {
+	Key     string
+	Service Service[Req, Resp]
+}
+
+// Discover tells the current set of instances of a service, which may change at any time.
+type Discover[Req, Resp any] interface {
+	// Instances returns the current instances and a version which changes whenever they change,
+	// so callers may cache what they derive from the instances.
+	// The returned slice must not be modified.
+	Instances() ([]Instance[Req, Resp], uint64)
+}
+
+type snapshot[Req, Resp any] struct {
+	instances []Instance[Req, Resp]
+	version   uint64
+}
+
+// StaticDiscover is a [Discover] whose instances are set explicitly,
+// it's the building block of discovery sources like DNS or a registry.
+//
+// The zero value has no instances and is ready to use.
+type StaticDiscover[Req, Resp any] struct {
+	mu   sync.Mutex
+	snap atomic.Pointer[snapshot[Req, Resp]]
+}
+
+// NewStaticDiscover creates a [StaticDiscover] with the given instances.
+func NewStaticDiscover[Req, Resp any](instances ...Instance[Req, Resp]) *StaticDiscover[Req, Resp] {
+	d := &StaticDiscover[Req, Resp]{}
+	d.Set(instances...)
+	return d
+}
+
+// Instances implements [Discover].
+func (self *StaticDiscover[Req, Resp]) Instances() ([]Instance[Req, Resp], uint64) {
+	if s := self.snap.Load(); s != nil {
+		return s.instances, s.version
+	}
+	return nil, 0
+}
+
+func (self *StaticDiscover[Req, Resp]) update(f func([]Instance[Req, Resp]) []Instance[Req, Resp]) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	cur, version := self.Instances()
+	self.snap.Store(&snapshot[Req, Resp]{instances: f(cur), version: version + 1})
+}
+
+// Set replaces all instances.
+func (self *StaticDiscover[Req, Resp]) Set(instances ...Instance[Req, Resp]) {
+	self.update(func([]Instance[Req, Resp]) []Instance[Req, Resp] {
+		return append([]Instance[Req, Resp](nil), instances...)
+	})
+}
+
+// Add adds an instance, or replaces the instance of the same key.
+func (self *StaticDiscover[Req, Resp]) Add(instance Instance[Req, Resp]) {
+	self.update(func(cur []Instance[Req, Resp]) []Instance[Req, Resp] {
+		next := make([]Instance[Req, Resp], 0, len(cur)+1)
+		for _, i := range cur {
+			if i.Key != instance.Key {
+				next = append(next, i)
+			}
+		}
+		return append(next, instance)
+	})
+}
+
+// Remove removes the instance of key.
+func (self *StaticDiscover[Req, Resp]) Remove(key string) {
+	self.update(func(cur []Instance[Req, Resp]) []Instance[Req, Resp] {
+		next := make([]Instance[Req, Resp], 0, len(cur))
+		for _, i := range cur {
+			if i.Key != key {
+				next = append(next, i)
+			}
+		}
+		return next
+	})
+}
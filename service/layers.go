@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-board/std/lazy"
+	"github.com/go-board/std/result"
+	xsync "github.com/go-board/std/sync"
+)
+
+var (
+	// ErrOverloaded is returned by [LoadShed] when the service is at capacity.
+	ErrOverloaded = errors.New("service: overloaded")
+	// ErrRateLimited is returned by [RateLimit] when no token is available.
+	ErrRateLimited = errors.New("service: rate limited")
+	// ErrCircuitOpen is returned by [Breaker] while the circuit breaker rejects requests.
+	ErrCircuitOpen = errors.New("service: circuit breaker is open")
+)
+
+// layer builds a layer wrapping each service with f.
+func layer[Req, Resp any](f func(s Service[Req, Resp], ctx context.Context, req Req) result.Result[Resp]) Layer[Service[Req, Resp]] {
+	return LayerFn[Service[Req, Resp]](func(s Service[Req, Resp]) Service[Req, Resp] {
+		return ServiceFn[Req, Resp](func(ctx context.Context, req Req) result.Result[Resp] { return f(s, ctx, req) })
+	})
+}
+
+// Timeout bounds each call by d measured on clock, on top of the deadline the ctx of
+// the caller may have, clock defaults to [SystemClock] if nil.
+//
+// The call returns ctx.Err() once the deadline is exceeded, even if the inner service
+// doesn't return, in that case it keeps running on its goroutine until it does.
+// A panic of the inner service is raised again on the goroutine of the caller,
+// so outer layers like [Recover] and [Breaker] see it.
+func Timeout[Req, Resp any](d time.Duration, clock Clock) Layer[Service[Req, Resp]] {
+	clock = clockOrSystem(clock)
+	type callResult struct {
+		r        result.Result[Resp]
+		panicked bool
+		value    any
+	}
+	return layer(func(s Service[Req, Resp], ctx context.Context, req Req) result.Result[Resp] {
+		ctx, cancel := withTimeout(ctx, clock, d)
+		defer cancel()
+		ch := make(chan callResult, 1)
+		go func() {
+			returned := false
+			defer func() {
+				// a nil value is runtime.Goexit, which counts as never returning.
+				if p := recover(); !returned && p != nil {
+					ch <- callResult{panicked: true, value: p}
+				}
+			}()
+			r := s.Call(ctx, req)
+			returned = true
+			ch <- callResult{r: r}
+		}()
+		select {
+		case o := <-ch:
+			if o.panicked {
+				panic(o.value)
+			}
+			return o.r
+		case <-ctx.Done():
+			return result.Err[Resp](ctx.Err())
+		}
+	})
+}
+
+// RetryPolicy configures [Retry].
+type RetryPolicy struct {
+	// MaxAttempts is the number of calls including the first one, defaults to 3.
+	MaxAttempts int
+	// Backoff returns how long to wait after the given number of failures,
+	// e.g. [lazy.ExponentialBackoff]. No waiting if nil.
+	Backoff func(failures int) time.Duration
+	// Retryable reports whether a call failed with err is worth retrying,
+	// all errors are retried if nil.
+	Retryable func(err error) bool
+	// Clock waits for backoff, defaults to [SystemClock].
+	Clock Clock
+}
+
+// ExponentialBackoff is [lazy.ExponentialBackoff], for [RetryPolicy.Backoff].
+func ExponentialBackoff(base, max time.Duration) func(failures int) time.Duration {
+	return lazy.ExponentialBackoff(base, max)
+}
+
+// Retry calls the service again when it fails with a retryable error, as configured by policy.
+//
+// Retrying stops once ctx is done, the last result is returned when attempts are exhausted.
+func Retry[Req, Resp any](policy RetryPolicy) Layer[Service[Req, Resp]] {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 3
+	}
+	clock := clockOrSystem(policy.Clock)
+	return layer(func(s Service[Req, Resp], ctx context.Context, req Req) result.Result[Resp] {
+		for failures := 0; ; {
+			r := s.Call(ctx, req)
+			if r.IsOk() || (policy.Retryable != nil && !policy.Retryable(r.Error())) {
+				return r
+			}
+			if failures++; failures >= policy.MaxAttempts || ctx.Err() != nil {
+				return r
+			}
+			if policy.Backoff != nil {
+				select {
+				case <-clock.After(policy.Backoff(failures)):
+				case <-ctx.Done():
+					return r
+				}
+			}
+		}
+	})
+}
+
+// ConcurrencyLimit allows at most n calls in flight, more calls wait for their turn
+// or return ctx.Err() if ctx is done first.
+//
+// If n less than 1, it's set to 1.
+func ConcurrencyLimit[Req, Resp any](n int) Layer[Service[Req, Resp]] {
+	if n < 1 {
+		n = 1
+	}
+	sem := make(chan struct{}, n)
+	return layer(func(s Service[Req, Resp], ctx context.Context, req Req) result.Result[Resp] {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return result.Err[Resp](ctx.Err())
+		}
+		defer func() { <-sem }()
+		return s.Call(ctx, req)
+	})
+}
+
+// LoadShed allows at most n calls in flight, more calls fail with [ErrOverloaded] immediately,
+// instead of queueing up like [ConcurrencyLimit].
+//
+// If n less than 1, it's set to 1.
+func LoadShed[Req, Resp any](n int) Layer[Service[Req, Resp]] {
+	if n < 1 {
+		n = 1
+	}
+	var inflight atomic.Int64
+	return layer(func(s Service[Req, Resp], ctx context.Context, req Req) result.Result[Resp] {
+		if inflight.Add(1) > int64(n) {
+			inflight.Add(-1)
+			return result.Err[Resp](ErrOverloaded)
+		}
+		defer inflight.Add(-1)
+		return s.Call(ctx, req)
+	})
+}
+
+// Recover turns a panic of the service into an error result of [*sync.PanicError].
+func Recover[Req, Resp any]() Layer[Service[Req, Resp]] {
+	return layer(func(s Service[Req, Resp], ctx context.Context, req Req) (r result.Result[Resp]) {
+		defer func() {
+			if p := recover(); p != nil {
+				r = result.Err[Resp](&xsync.PanicError{Value: p, Stack: debug.Stack()})
+			}
+		}()
+		return s.Call(ctx, req)
+	})
+}
@@ -0,0 +1,285 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/result"
+	"github.com/go-board/std/service"
+	xsync "github.com/go-board/std/sync"
+)
+
+var errBackend = errors.New("backend failed")
+
+// fakeClock only moves when told, After fires at once and moves the clock forward.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock { return &fakeClock{now: time.Unix(0, 0)} }
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.Advance(d)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sleeps = append(c.sleeps, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// flaky fails the first n calls.
+func flaky(n int) (service.Service[int, int], *atomic.Int32) {
+	calls := &atomic.Int32{}
+	return service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		if int(calls.Add(1)) <= n {
+			return result.Err[int](errBackend)
+		}
+		return result.Ok(req)
+	}), calls
+}
+
+// timerClock fires the channels returned by After only when told by Fire.
+type timerClock struct{ fire chan time.Time }
+
+func newTimerClock() *timerClock { return &timerClock{fire: make(chan time.Time)} }
+
+func (c *timerClock) Now() time.Time                       { return time.Unix(0, 0) }
+func (c *timerClock) After(time.Duration) <-chan time.Time { return c.fire }
+func (c *timerClock) Fire()                                { c.fire <- time.Unix(0, 0) }
+
+func TestTimeout(t *testing.T) {
+	clock := newTimerClock()
+	seen := make(chan error, 1)
+	s := service.Timeout[int, int](time.Second, clock).Then(service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		deadline, _ := ctx.Deadline()
+		qt.Check(t, deadline, qt.Equals, time.Unix(1, 0))
+		if req == 0 {
+			<-ctx.Done()
+			seen <- ctx.Err()
+		}
+		return result.Ok(req)
+	}))
+	qt.Assert(t, s.Call(context.Background(), 1).Value(), qt.Equals, 1)
+
+	done := make(chan result.Result[int])
+	go func() { done <- s.Call(context.Background(), 0) }()
+	clock.Fire()
+	qt.Assert(t, (<-done).Error(), qt.Equals, context.DeadlineExceeded)
+	qt.Assert(t, <-seen, qt.Equals, context.DeadlineExceeded)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { done <- s.Call(ctx, 0) }()
+	cancel()
+	qt.Assert(t, (<-done).Error(), qt.Equals, context.Canceled)
+	qt.Assert(t, <-seen, qt.Equals, context.Canceled)
+}
+
+func TestTimeout_Panic(t *testing.T) {
+	s := service.Chain(
+		service.Recover[int, int](),
+		service.Timeout[int, int](time.Second, nil),
+	).Then(service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		panic("boom")
+	}))
+	var pe *xsync.PanicError
+	qt.Assert(t, errors.As(s.Call(context.Background(), 1).Error(), &pe), qt.IsTrue)
+	qt.Assert(t, pe.Value, qt.Equals, "boom")
+}
+
+func TestRetry(t *testing.T) {
+	clock := newFakeClock()
+	inner, calls := flaky(2)
+	s := service.Retry[int, int](service.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     service.ExponentialBackoff(time.Second, time.Minute),
+		Clock:       clock,
+	}).Then(inner)
+	qt.Assert(t, s.Call(context.Background(), 7).Value(), qt.Equals, 7)
+	qt.Assert(t, calls.Load(), qt.Equals, int32(3))
+	qt.Assert(t, clock.sleeps, qt.DeepEquals, []time.Duration{time.Second, 2 * time.Second})
+
+	inner, calls = flaky(5)
+	s = service.Retry[int, int](service.RetryPolicy{MaxAttempts: 2}).Then(inner)
+	qt.Assert(t, s.Call(context.Background(), 7).Error(), qt.Equals, errBackend)
+	qt.Assert(t, calls.Load(), qt.Equals, int32(2))
+
+	inner, calls = flaky(5)
+	s = service.Retry[int, int](service.RetryPolicy{Retryable: func(err error) bool { return false }}).Then(inner)
+	qt.Assert(t, s.Call(context.Background(), 7).Error(), qt.Equals, errBackend)
+	qt.Assert(t, calls.Load(), qt.Equals, int32(1))
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	var inflight, peak atomic.Int32
+	s := service.ConcurrencyLimit[int, int](2).Then(service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		n := inflight.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(time.Millisecond)
+		inflight.Add(-1)
+		return result.Ok(req)
+	}))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			qt.Check(t, s.Call(context.Background(), i).Value(), qt.Equals, i)
+		}(i)
+	}
+	wg.Wait()
+	qt.Assert(t, peak.Load() <= 2, qt.IsTrue)
+}
+
+func TestLoadShed(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	s := service.LoadShed[int, int](1).Then(service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		close(started)
+		<-release
+		return result.Ok(req)
+	}))
+	done := make(chan result.Result[int])
+	go func() { done <- s.Call(context.Background(), 1) }()
+	<-started
+	qt.Assert(t, s.Call(context.Background(), 2).Error(), qt.Equals, service.ErrOverloaded)
+	close(release)
+	qt.Assert(t, (<-done).Value(), qt.Equals, 1)
+}
+
+func TestRecover(t *testing.T) {
+	s := service.Recover[int, int]().Then(service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		panic("boom")
+	}))
+	var pe *xsync.PanicError
+	qt.Assert(t, errors.As(s.Call(context.Background(), 1).Error(), &pe), qt.IsTrue)
+	qt.Assert(t, pe.Value, qt.Equals, "boom")
+}
+
+func TestRateLimit(t *testing.T) {
+	clock := newFakeClock()
+	bucket := service.NewTokenBucket(2, 2, clock)
+	inner, _ := flaky(0)
+	s := service.RateLimit[int, int](bucket).Then(inner)
+	qt.Assert(t, s.Call(context.Background(), 1).IsOk(), qt.IsTrue)
+	qt.Assert(t, s.Call(context.Background(), 1).IsOk(), qt.IsTrue)
+	qt.Assert(t, s.Call(context.Background(), 1).Error(), qt.Equals, service.ErrRateLimited)
+	clock.Advance(500 * time.Millisecond)
+	qt.Assert(t, s.Call(context.Background(), 1).IsOk(), qt.IsTrue)
+	qt.Assert(t, s.Call(context.Background(), 1).Error(), qt.Equals, service.ErrRateLimited)
+	clock.Advance(time.Hour)
+	for i := 0; i < 2; i++ {
+		qt.Assert(t, s.Call(context.Background(), 1).IsOk(), qt.IsTrue)
+	}
+	qt.Assert(t, s.Call(context.Background(), 1).Error(), qt.Equals, service.ErrRateLimited)
+}
+
+func TestBreaker(t *testing.T) {
+	clock := newFakeClock()
+	var transitions []string
+	breaker := service.NewCircuitBreaker(service.BreakerConfig{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Second,
+		HalfOpenCalls:    1,
+		Clock:            clock,
+		OnStateChange: func(from, to service.BreakerState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	fail := true
+	s := service.Breaker[int, int](breaker).Then(service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		if fail {
+			return result.Err[int](errBackend)
+		}
+		return result.Ok(req)
+	}))
+
+	qt.Assert(t, s.Call(context.Background(), 1).Error(), qt.Equals, errBackend)
+	qt.Assert(t, breaker.State(), qt.Equals, service.StateClosed)
+	qt.Assert(t, s.Call(context.Background(), 1).Error(), qt.Equals, errBackend)
+	qt.Assert(t, breaker.State(), qt.Equals, service.StateOpen)
+	qt.Assert(t, s.Call(context.Background(), 1).Error(), qt.Equals, service.ErrCircuitOpen)
+
+	clock.Advance(time.Second)
+	qt.Assert(t, breaker.State(), qt.Equals, service.StateHalfOpen)
+	qt.Assert(t, s.Call(context.Background(), 1).Error(), qt.Equals, errBackend)
+	qt.Assert(t, breaker.State(), qt.Equals, service.StateOpen)
+
+	clock.Advance(time.Second)
+	fail = false
+	qt.Assert(t, s.Call(context.Background(), 1).Value(), qt.Equals, 1)
+	qt.Assert(t, breaker.State(), qt.Equals, service.StateClosed)
+	qt.Assert(t, transitions, qt.DeepEquals, []string{
+		"closed->open", "open->half-open", "half-open->open", "open->half-open", "half-open->closed",
+	})
+}
+
+func TestBreaker_PanickingTrial(t *testing.T) {
+	clock := newFakeClock()
+	breaker := service.NewCircuitBreaker(service.BreakerConfig{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Second,
+		HalfOpenCalls:    1,
+		Clock:            clock,
+	})
+	panics := true
+	s := service.Breaker[int, int](breaker).Then(service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		if panics {
+			panic("boom")
+		}
+		return result.Ok(req)
+	}))
+	qt.Assert(t, func() { s.Call(context.Background(), 1) }, qt.PanicMatches, "boom")
+	qt.Assert(t, breaker.State(), qt.Equals, service.StateOpen)
+
+	clock.Advance(time.Second)
+	qt.Assert(t, breaker.State(), qt.Equals, service.StateHalfOpen)
+	qt.Assert(t, func() { s.Call(context.Background(), 1) }, qt.PanicMatches, "boom")
+	// the panicking trial reopens the breaker instead of holding its slot.
+	qt.Assert(t, breaker.State(), qt.Equals, service.StateOpen)
+
+	clock.Advance(time.Second)
+	panics = false
+	qt.Assert(t, s.Call(context.Background(), 1).Value(), qt.Equals, 1)
+	qt.Assert(t, breaker.State(), qt.Equals, service.StateClosed)
+}
+
+func TestLimits_NonPositive(t *testing.T) {
+	inner, _ := flaky(0)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	qt.Assert(t, service.ConcurrencyLimit[int, int](0).Then(inner).Call(ctx, 1).Value(), qt.Equals, 1)
+	qt.Assert(t, service.LoadShed[int, int](-1).Then(inner).Call(ctx, 1).Value(), qt.Equals, 1)
+}
+
+func TestChain(t *testing.T) {
+	clock := newFakeClock()
+	inner, calls := flaky(1)
+	s := service.Chain(
+		service.Recover[int, int](),
+		service.Retry[int, int](service.RetryPolicy{Clock: clock}),
+		service.ConcurrencyLimit[int, int](1),
+	).Then(inner)
+	qt.Assert(t, s.Call(context.Background(), 3).Value(), qt.Equals, 3)
+	qt.Assert(t, calls.Load(), qt.Equals, int32(2))
+}
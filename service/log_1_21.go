@@ -0,0 +1,52 @@
+//go:build go1.21
+
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/go-board/std/result"
+)
+
+// LogConfig configures [Logging].
+type LogConfig struct {
+	// Name is the value of the service attribute.
+	Name string
+	// Logger receives the records, defaults to [slog.Default].
+	Logger *slog.Logger
+	// Level is the level of successful calls, failed calls are logged at [slog.LevelError].
+	Level slog.Level
+	// Classify sets the class attribute of failed calls, defaults to [Classify].
+	Classify func(error) string
+	// Clock measures durations, defaults to [SystemClock].
+	Clock Clock
+}
+
+// Logging logs a structured record of every call, with attributes service, request_id
+// if the context carries one, duration and outcome, plus error and class for failed calls.
+// A call which panics is logged as failed before the panic goes on.
+func Logging[Req, Resp any](cfg LogConfig) Layer[Service[Req, Resp]] {
+	clock := clockOrSystem(cfg.Clock)
+	classify := classifyOrDefault(cfg.Classify)
+	return layer(func(s Service[Req, Resp], ctx context.Context, req Req) result.Result[Resp] {
+		logger := cfg.Logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		start := clock.Now()
+		return observe(s, ctx, req, func(err error) {
+			level := cfg.Level
+			attrs := []slog.Attr{slog.String("service", cfg.Name)}
+			if id, ok := RequestID(ctx); ok {
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+			attrs = append(attrs, slog.Duration("duration", clock.Now().Sub(start)), slog.String("outcome", outcome(err)))
+			if err != nil {
+				level = slog.LevelError
+				attrs = append(attrs, slog.Any("error", err), slog.String("class", classify(err)))
+			}
+			logger.LogAttrs(ctx, level, "service call", attrs...)
+		})
+	})
+}
@@ -0,0 +1,51 @@
+//go:build go1.21
+
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/result"
+	"github.com/go-board/std/service"
+)
+
+func TestLogging(t *testing.T) {
+	clock := newFakeClock()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	s := service.Logging[int, int](service.LogConfig{Name: "echo", Logger: logger, Clock: clock}).Then(slowEcho(clock))
+
+	s.Call(service.WithRequestID(context.Background(), "req-1"), 1)
+	var record map[string]any
+	qt.Assert(t, json.Unmarshal(buf.Bytes(), &record), qt.IsNil)
+	qt.Assert(t, record["level"], qt.Equals, "INFO")
+	qt.Assert(t, record["service"], qt.Equals, "echo")
+	qt.Assert(t, record["request_id"], qt.Equals, "req-1")
+	qt.Assert(t, record["outcome"], qt.Equals, "ok")
+	qt.Assert(t, record["duration"], qt.Equals, 1e9)
+
+	buf.Reset()
+	s.Call(context.Background(), -1)
+	record = nil
+	qt.Assert(t, json.Unmarshal(buf.Bytes(), &record), qt.IsNil)
+	qt.Assert(t, record["level"], qt.Equals, "ERROR")
+	qt.Assert(t, record["outcome"], qt.Equals, "err")
+	qt.Assert(t, record["class"], qt.Equals, "timeout")
+	_, ok := record["request_id"]
+	qt.Assert(t, ok, qt.IsFalse)
+
+	buf.Reset()
+	panicking := service.Logging[int, int](service.LogConfig{Name: "echo", Logger: logger, Clock: clock}).Then(service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		panic("boom")
+	}))
+	qt.Assert(t, func() { panicking.Call(context.Background(), 1) }, qt.PanicMatches, "boom")
+	record = nil
+	qt.Assert(t, json.Unmarshal(buf.Bytes(), &record), qt.IsNil)
+	qt.Assert(t, record["level"], qt.Equals, "ERROR")
+	qt.Assert(t, record["class"], qt.Equals, "panic")
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-board/std/result"
+)
+
+// Metric names recorded by [Metrics].
+const (
+	// MetricCalls counts calls, labeled by service, outcome and class.
+	MetricCalls = "service_calls_total"
+	// MetricDuration observes the duration of calls in seconds, labeled by service and outcome.
+	MetricDuration = "service_call_duration_seconds"
+)
+
+// Label is a dimension of a metric.
+type Label struct {
+	Key, Value string
+}
+
+// Recorder receives metrics, it's the adapter to a metrics system like Prometheus.
+//
+// Implementations must be safe for concurrent use.
+type Recorder interface {
+	// Add adds delta to the counter of name and labels.
+	Add(name string, delta float64, labels ...Label)
+	// Observe records a value in the histogram of name and labels.
+	Observe(name string, value float64, labels ...Label)
+}
+
+// MemoryRecorder is a [Recorder] keeping metrics in memory, it's meant for tests.
+type MemoryRecorder struct {
+	mu         sync.Mutex
+	counters   map[string]float64
+	histograms map[string][]float64
+}
+
+// NewMemoryRecorder creates an empty [MemoryRecorder].
+func NewMemoryRecorder() *MemoryRecorder {
+	return &MemoryRecorder{counters: make(map[string]float64), histograms: make(map[string][]float64)}
+}
+
+// metricKey identifies a metric by its name and labels, whatever the order of labels.
+func metricKey(name string, labels []Label) string {
+	sorted := append([]Label(nil), labels...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+	var b strings.Builder
+	b.WriteString(name)
+	b.WriteByte('{')
+	for i, l := range sorted {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(l.Key)
+		b.WriteByte('=')
+		b.WriteString(l.Value)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// Add implements [Recorder].
+func (self *MemoryRecorder) Add(name string, delta float64, labels ...Label) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.counters[metricKey(name, labels)] += delta
+}
+
+// Observe implements [Recorder].
+func (self *MemoryRecorder) Observe(name string, value float64, labels ...Label) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	key := metricKey(name, labels)
+	self.histograms[key] = append(self.histograms[key], value)
+}
+
+// Counter returns the value of the counter of name and labels.
+func (self *MemoryRecorder) Counter(name string, labels ...Label) float64 {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.counters[metricKey(name, labels)]
+}
+
+// Histogram returns the values observed in the histogram of name and labels.
+func (self *MemoryRecorder) Histogram(name string, labels ...Label) []float64 {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return append([]float64(nil), self.histograms[metricKey(name, labels)]...)
+}
+
+// MetricsConfig configures [Metrics].
+type MetricsConfig struct {
+	// Name is the value of the service label.
+	Name string
+	// Recorder receives the metrics.
+	Recorder Recorder
+	// Classify sets the class label of failed calls, defaults to [Classify].
+	Classify func(error) string
+	// Clock measures durations, defaults to [SystemClock].
+	Clock Clock
+}
+
+// Metrics records [MetricCalls] and [MetricDuration] of every call,
+// a call which panics is recorded as failed before the panic goes on.
+func Metrics[Req, Resp any](cfg MetricsConfig) Layer[Service[Req, Resp]] {
+	clock := clockOrSystem(cfg.Clock)
+	classify := classifyOrDefault(cfg.Classify)
+	return layer(func(s Service[Req, Resp], ctx context.Context, req Req) result.Result[Resp] {
+		start := clock.Now()
+		return observe(s, ctx, req, func(err error) {
+			elapsed := clock.Now().Sub(start)
+			svc, out := Label{"service", cfg.Name}, Label{"outcome", outcome(err)}
+			cfg.Recorder.Add(MetricCalls, 1, svc, out, Label{"class", classify(err)})
+			cfg.Recorder.Observe(MetricDuration, elapsed.Seconds(), svc, out)
+		})
+	})
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"github.com/go-board/std/result"
+	xsync "github.com/go-board/std/sync"
+)
+
+// ErrPanicked is the error observability layers record for a call which panicked,
+// or exited its goroutine by [runtime.Goexit], while the panic goes on untouched.
+var ErrPanicked = errors.New("service: call panicked")
+
+type requestIDKey struct{}
+
+// WithRequestID returns a ctx carrying id as the request ID,
+// observability layers tag what they record with it.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx.
+func RequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok
+}
+
+// NewRequestID returns a random request ID of 16 hex digits.
+func NewRequestID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Classify returns a coarse class of err for observability, so errors can be counted
+// and searched by kind rather than by message.
+//
+// It's "ok" for nil, "timeout", "canceled", "overloaded", "rate_limited", "circuit_open",
+// "unavailable" and "panic" for the errors of context and of this package's layers,
+// and "error" for all others.
+func Classify(err error) string {
+	var pe *xsync.PanicError
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, ErrOverloaded):
+		return "overloaded"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, ErrNoInstances), errors.Is(err, ErrNoRoute):
+		return "unavailable"
+	case errors.As(err, &pe), errors.Is(err, ErrPanicked):
+		return "panic"
+	}
+	return "error"
+}
+
+// observe calls s and then done with the error of the call.
+//
+// If s doesn't return, done gets [ErrPanicked] without recovering, so the panic or
+// [runtime.Goexit] goes on from its origin, and observability layers record every call
+// wherever they're put.
+func observe[Req, Resp any](s Service[Req, Resp], ctx context.Context, req Req, done func(err error)) result.Result[Resp] {
+	returned := false
+	defer func() {
+		if !returned {
+			done(ErrPanicked)
+		}
+	}()
+	r := s.Call(ctx, req)
+	returned = true
+	done(r.ErrorOr(nil))
+	return r
+}
+
+func outcome(err error) string {
+	if err == nil {
+		return "ok"
+	}
+	return "err"
+}
+
+func classifyOrDefault(f func(error) string) func(error) string {
+	if f == nil {
+		return Classify
+	}
+	return f
+}
@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/result"
+	"github.com/go-board/std/service"
+	xsync "github.com/go-board/std/sync"
+)
+
+// slowEcho takes a second of clock to answer, and fails negative requests.
+func slowEcho(clock *fakeClock) service.Service[int, int] {
+	return service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		clock.Advance(time.Second)
+		if req < 0 {
+			return result.Err[int](context.DeadlineExceeded)
+		}
+		return result.Ok(req)
+	})
+}
+
+func TestClassify(t *testing.T) {
+	for err, class := range map[error]string{
+		nil: "ok",
+		fmt.Errorf("call: %w", context.DeadlineExceeded): "timeout",
+		context.Canceled:                 "canceled",
+		service.ErrOverloaded:            "overloaded",
+		service.ErrRateLimited:           "rate_limited",
+		service.ErrCircuitOpen:           "circuit_open",
+		service.ErrNoRoute:               "unavailable",
+		&xsync.PanicError{Value: "boom"}: "panic",
+		service.ErrPanicked:              "panic",
+		errBackend:                       "error",
+	} {
+		qt.Check(t, service.Classify(err), qt.Equals, class)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	_, ok := service.RequestID(context.Background())
+	qt.Assert(t, ok, qt.IsFalse)
+	id, ok := service.RequestID(service.WithRequestID(context.Background(), "req-1"))
+	qt.Assert(t, ok, qt.IsTrue)
+	qt.Assert(t, id, qt.Equals, "req-1")
+	qt.Assert(t, service.NewRequestID(), qt.HasLen, 16)
+}
+
+func TestMetrics(t *testing.T) {
+	clock := newFakeClock()
+	rec := service.NewMemoryRecorder()
+	s := service.Metrics[int, int](service.MetricsConfig{Name: "echo", Recorder: rec, Clock: clock}).Then(slowEcho(clock))
+	s.Call(context.Background(), 1)
+	s.Call(context.Background(), 2)
+	s.Call(context.Background(), -1)
+
+	svc := service.Label{Key: "service", Value: "echo"}
+	ok := service.Label{Key: "outcome", Value: "ok"}
+	qt.Assert(t, rec.Counter(service.MetricCalls, svc, ok, service.Label{Key: "class", Value: "ok"}), qt.Equals, 2.0)
+	// labels match whatever their order.
+	qt.Assert(t, rec.Counter(service.MetricCalls, service.Label{Key: "class", Value: "timeout"}, svc, service.Label{Key: "outcome", Value: "err"}), qt.Equals, 1.0)
+	qt.Assert(t, rec.Histogram(service.MetricDuration, svc, ok), qt.DeepEquals, []float64{1, 1})
+}
+
+func TestTracing(t *testing.T) {
+	clock := newFakeClock()
+	var tracer service.SpanRecorder
+	var seen string
+	inner := service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		seen, _ = service.RequestID(ctx)
+		return slowEcho(clock).Call(ctx, req)
+	})
+	s := service.Tracing[int, int](service.TraceConfig{
+		Name:         "echo",
+		Tracer:       &tracer,
+		NewRequestID: func() string { return "generated" },
+		Clock:        clock,
+	}).Then(inner)
+
+	s.Call(context.Background(), 1)
+	qt.Assert(t, seen, qt.Equals, "generated")
+	s.Call(service.WithRequestID(context.Background(), "given"), -1)
+	qt.Assert(t, seen, qt.Equals, "given")
+
+	spans := tracer.Spans()
+	qt.Assert(t, spans, qt.HasLen, 2)
+	qt.Assert(t, spans[0].Name, qt.Equals, "echo")
+	qt.Assert(t, spans[0].RequestID, qt.Equals, "generated")
+	qt.Assert(t, spans[0].Ok(), qt.IsTrue)
+	qt.Assert(t, spans[0].Class, qt.Equals, "ok")
+	qt.Assert(t, spans[0].Duration, qt.Equals, time.Second)
+	qt.Assert(t, spans[1].RequestID, qt.Equals, "given")
+	qt.Assert(t, spans[1].Ok(), qt.IsFalse)
+	qt.Assert(t, spans[1].Class, qt.Equals, "timeout")
+}
+
+func TestObserve_Panic(t *testing.T) {
+	clock := newFakeClock()
+	var tracer service.SpanRecorder
+	rec := service.NewMemoryRecorder()
+	s := service.Chain(
+		service.Tracing[int, int](service.TraceConfig{Name: "echo", Tracer: &tracer, Clock: clock}),
+		service.Metrics[int, int](service.MetricsConfig{Name: "echo", Recorder: rec, Clock: clock}),
+	).Then(service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		clock.Advance(time.Second)
+		panic("boom")
+	}))
+	qt.Assert(t, func() { s.Call(context.Background(), 1) }, qt.PanicMatches, "boom")
+
+	spans := tracer.Spans()
+	qt.Assert(t, spans, qt.HasLen, 1)
+	qt.Assert(t, spans[0].Class, qt.Equals, "panic")
+	qt.Assert(t, spans[0].Duration, qt.Equals, time.Second)
+	svc, failed := service.Label{Key: "service", Value: "echo"}, service.Label{Key: "outcome", Value: "err"}
+	qt.Assert(t, rec.Counter(service.MetricCalls, svc, failed, service.Label{Key: "class", Value: "panic"}), qt.Equals, 1.0)
+	qt.Assert(t, rec.Histogram(service.MetricDuration, svc, failed), qt.DeepEquals, []float64{1})
+}
+
+func TestObserve_Goexit(t *testing.T) {
+	var tracer service.SpanRecorder
+	rec := service.NewMemoryRecorder()
+	s := service.Chain(
+		service.Tracing[int, int](service.TraceConfig{Name: "echo", Tracer: &tracer}),
+		service.Metrics[int, int](service.MetricsConfig{Name: "echo", Recorder: rec}),
+	).Then(service.ServiceFn[int, int](func(ctx context.Context, req int) result.Result[int] {
+		runtime.Goexit()
+		return result.Ok(req)
+	}))
+	exited := make(chan struct{})
+	returned := false
+	go func() {
+		defer close(exited)
+		s.Call(context.Background(), 1)
+		returned = true
+	}()
+	<-exited
+	// the goroutine exits quietly instead of panicking, and the call is recorded.
+	qt.Assert(t, returned, qt.IsFalse)
+	spans := tracer.Spans()
+	qt.Assert(t, spans, qt.HasLen, 1)
+	qt.Assert(t, spans[0].Err, qt.Equals, service.ErrPanicked)
+	svc, failed := service.Label{Key: "service", Value: "echo"}, service.Label{Key: "outcome", Value: "err"}
+	qt.Assert(t, rec.Counter(service.MetricCalls, svc, failed, service.Label{Key: "class", Value: "panic"}), qt.Equals, 1.0)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-board/std/result"
+)
+
+// TokenBucket is a rate limiter refilled with rate tokens per second and holding at most burst tokens,
+// every allowed request takes a token.
+type TokenBucket struct {
+	rate  float64
+	burst float64
+	clock Clock
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket creates a full [TokenBucket], clock defaults to [SystemClock] if nil.
+func NewTokenBucket(rate float64, burst int, clock Clock) *TokenBucket {
+	clock = clockOrSystem(clock)
+	return &TokenBucket{rate: rate, burst: float64(burst), clock: clock, tokens: float64(burst), last: clock.Now()}
+}
+
+// Allow takes a token and reports whether one is available.
+func (self *TokenBucket) Allow() bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	now := self.clock.Now()
+	if elapsed := now.Sub(self.last); elapsed > 0 {
+		self.tokens += elapsed.Seconds() * self.rate
+		if self.tokens > self.burst {
+			self.tokens = self.burst
+		}
+	}
+	self.last = now
+	if self.tokens < 1 {
+		return false
+	}
+	self.tokens--
+	return true
+}
+
+// RateLimit rejects calls with [ErrRateLimited] when bucket has no token left.
+//
+// The bucket may be shared by several services to limit them together.
+func RateLimit[Req, Resp any](bucket *TokenBucket) Layer[Service[Req, Resp]] {
+	return layer(func(s Service[Req, Resp], ctx context.Context, req Req) result.Result[Resp] {
+		if !bucket.Allow() {
+			return result.Err[Resp](ErrRateLimited)
+		}
+		return s.Call(ctx, req)
+	})
+}
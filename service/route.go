@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-board/std/result"
+)
+
+// ErrNoRoute is returned by routers when no service matches a request.
+var ErrNoRoute = errors.New("service: no route")
+
+type route[Req, Resp any] struct {
+	match func(Req) bool
+	s     Service[Req, Resp]
+}
+
+// Router dispatches each request to the service of the first route matching it.
+//
+// Example:
+//
+//	router := NewRouter[Request, Response]().
+//		Route(func(r Request) bool { return r.Method == "GET" }, reads).
+//		Default(writes)
+type Router[Req, Resp any] struct {
+	routes   []route[Req, Resp]
+	fallback Service[Req, Resp]
+}
+
+// NewRouter creates a [Router] without routes.
+func NewRouter[Req, Resp any]() *Router[Req, Resp] { return &Router[Req, Resp]{} }
+
+// Route adds a route to s for requests matched by match, routes are tried in the order they're added.
+func (self *Router[Req, Resp]) Route(match func(Req) bool, s Service[Req, Resp]) *Router[Req, Resp] {
+	self.routes = append(self.routes, route[Req, Resp]{match: match, s: s})
+	return self
+}
+
+// Default sets the service of requests matching no route, they fail with [ErrNoRoute] without it.
+func (self *Router[Req, Resp]) Default(s Service[Req, Resp]) *Router[Req, Resp] {
+	self.fallback = s
+	return self
+}
+
+// Call implements [Service].
+func (self *Router[Req, Resp]) Call(ctx context.Context, req Req) result.Result[Resp] {
+	for _, r := range self.routes {
+		if r.match(req) {
+			return r.s.Call(ctx, req)
+		}
+	}
+	if self.fallback != nil {
+		return self.fallback.Call(ctx, req)
+	}
+	return result.Err[Resp](ErrNoRoute)
+}
+
+// RouteByKey dispatches each request to the service of its key in routes,
+// requests of unknown keys go to fallback, or fail with [ErrNoRoute] if fallback is nil.
+func RouteByKey[Req, Resp any, K comparable](key func(Req) K, routes map[K]Service[Req, Resp], fallback Service[Req, Resp]) Service[Req, Resp] {
+	return ServiceFn[Req, Resp](func(ctx context.Context, req Req) result.Result[Resp] {
+		if s, ok := routes[key(req)]; ok {
+			return s.Call(ctx, req)
+		}
+		if fallback != nil {
+			return fallback.Call(ctx, req)
+		}
+		return result.Err[Resp](ErrNoRoute)
+	})
+}
+
+// Steer dispatches each request to services[pick(req, len(services))],
+// an index out of range fails with [ErrNoRoute].
+func Steer[Req, Resp any](pick func(req Req, n int) int, services ...Service[Req, Resp]) Service[Req, Resp] {
+	return ServiceFn[Req, Resp](func(ctx context.Context, req Req) result.Result[Resp] {
+		i := pick(req, len(services))
+		if i < 0 || i >= len(services) {
+			return result.Err[Resp](ErrNoRoute)
+		}
+		return services[i].Call(ctx, req)
+	})
+}
+
+// Fallback calls primary, and calls secondary instead when primary fails with an error
+// accepted by should, or any error if should is nil.
+func Fallback[Req, Resp any](primary, secondary Service[Req, Resp], should func(err error) bool) Service[Req, Resp] {
+	return ServiceFn[Req, Resp](func(ctx context.Context, req Req) result.Result[Resp] {
+		r := primary.Call(ctx, req)
+		if r.IsOk() || (should != nil && !should(r.Error())) {
+			return r
+		}
+		return secondary.Call(ctx, req)
+	})
+}
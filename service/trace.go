@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-board/std/result"
+)
+
+// Span describes a single call traced by [Tracing].
+type Span struct {
+	// Name is the name of the traced service.
+	Name string
+	// RequestID is the request ID carried by the context of the call.
+	RequestID string
+	// Start is when the call started.
+	Start time.Time
+	// Duration is how long the call took, it's set once the call ends.
+	Duration time.Duration
+	// Err is the error of the call, nil if it succeeded.
+	Err error
+	// Class is the class of Err, see [Classify].
+	Class string
+}
+
+// Ok reports whether the call succeeded.
+func (s *Span) Ok() bool { return s.Err == nil }
+
+// Tracer receives the spans of calls, it's the adapter to a tracing system like OpenTelemetry.
+//
+// Implementations must be safe for concurrent use.
+type Tracer interface {
+	// Start is called before a call, with the name, request ID and start time of span set.
+	// It returns the context the call runs with, e.g. carrying a span of the tracing system.
+	Start(ctx context.Context, span *Span) context.Context
+	// End is called after the call with the completed span, and the context returned by Start.
+	End(ctx context.Context, span *Span)
+}
+
+// SpanRecorder is a [Tracer] keeping ended spans in memory, it's meant for tests.
+type SpanRecorder struct {
+	mu    sync.Mutex
+	spans []Span
+}
+
+// Start implements [Tracer].
+func (self *SpanRecorder) Start(ctx context.Context, span *Span) context.Context { return ctx }
+
+// End implements [Tracer].
+func (self *SpanRecorder) End(ctx context.Context, span *Span) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	self.spans = append(self.spans, *span)
+}
+
+// Spans returns the ended spans in the order they ended.
+func (self *SpanRecorder) Spans() []Span {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return append([]Span(nil), self.spans...)
+}
+
+// TraceConfig configures [Tracing].
+type TraceConfig struct {
+	// Name is the name of spans.
+	Name string
+	// Tracer receives the spans.
+	Tracer Tracer
+	// NewRequestID generates the request ID of calls whose context carries none,
+	// defaults to [NewRequestID].
+	NewRequestID func() string
+	// Classify sets the class of spans, defaults to [Classify].
+	Classify func(error) string
+	// Clock measures durations, defaults to [SystemClock].
+	Clock Clock
+}
+
+// Tracing wraps every call in a span reported to the tracer.
+//
+// A call whose context carries no request ID gets a new one,
+// so inner layers and services see the same ID by [RequestID].
+// A call which panics ends its span with [ErrPanicked] before the panic goes on.
+func Tracing[Req, Resp any](cfg TraceConfig) Layer[Service[Req, Resp]] {
+	clock := clockOrSystem(cfg.Clock)
+	classify := classifyOrDefault(cfg.Classify)
+	newID := cfg.NewRequestID
+	if newID == nil {
+		newID = NewRequestID
+	}
+	return layer(func(s Service[Req, Resp], ctx context.Context, req Req) result.Result[Resp] {
+		id, ok := RequestID(ctx)
+		if !ok {
+			id = newID()
+			ctx = WithRequestID(ctx, id)
+		}
+		span := &Span{Name: cfg.Name, RequestID: id, Start: clock.Now()}
+		ctx = cfg.Tracer.Start(ctx, span)
+		return observe(s, ctx, req, func(err error) {
+			span.Duration = clock.Now().Sub(span.Start)
+			span.Err = err
+			span.Class = classify(err)
+			cfg.Tracer.End(ctx, span)
+		})
+	})
+}
@@ -446,6 +446,17 @@ func Single[T any, S ~[]T](slice S) result.Result[T] {
 	return result.Err[T](errors.New("slice is not scalar"))
 }
 
+// SortComparator sorts the given slice in-place using the given [cmp.Comparator].
+func SortComparator[T any, S ~[]T](slice S, c cmp.Comparator[T]) S {
+	return SortBy(slice, c.Cmp)
+}
+
+// SortStableComparator sorts the given slice in-place using the given [cmp.Comparator],
+// keeping the original order of equal elements.
+func SortStableComparator[T any, S ~[]T](slice S, c cmp.Comparator[T]) S {
+	return SortStableBy(slice, c.Cmp)
+}
+
 // ToHashMap converts the given slice to a map by the given key function.
 func ToHashMap[
 	T any,
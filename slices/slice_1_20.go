@@ -45,6 +45,13 @@ func SortBy[T any, S ~[]T](slice S, cmp func(lhs, rhs T) int) S {
 	return slice
 }
 
+// SortStableBy sorts the given slice in-place by the given compare function,
+// keeping the original order of equal elements.
+func SortStableBy[T any, S ~[]T](slice S, cmp func(lhs, rhs T) int) S {
+	sort.Stable(sortBy[T]{cmp: cmp, inner: slice})
+	return slice
+}
+
 // Sort sorts the given slice in-place.
 func Sort[T constraints.Ordered, S ~[]T](slice S) S {
 	return SortBy(slice, cmp.Compare[T])
@@ -36,6 +36,13 @@ func SortBy[T any, S ~[]T](slice S, cmp func(T, T) int) S {
 	return slice
 }
 
+// SortStableBy sorts the given slice in-place by the given compare function,
+// keeping the original order of equal elements.
+func SortStableBy[T any, S ~[]T](slice S, cmp func(T, T) int) S {
+	slices.SortStableFunc(slice, cmp)
+	return slice
+}
+
 func IsSorted[T cmp.Ordered, S ~[]T](slice S) bool {
 	return slices.IsSorted(slice)
 }
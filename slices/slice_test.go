@@ -54,6 +54,18 @@ func TestSort(t *testing.T) {
 		slices.Sort(slice)
 		a.Assert(slice, qt.DeepEquals, []int{1, 2, 3, 4, 7, 9, 11, 15})
 	})
+	t.Run("sort_comparator", func(t *testing.T) {
+		a := qt.New(t)
+		slice := []int{1, 15, 3, 2, 11, 4, 9, 7}
+		slices.SortComparator(slice, cmp.MakeComparator[int]())
+		a.Assert(slice, qt.DeepEquals, []int{1, 2, 3, 4, 7, 9, 11, 15})
+	})
+	t.Run("sort_stable_by", func(t *testing.T) {
+		a := qt.New(t)
+		slice := []item{{Value: 2, Name: "a"}, {Value: 1, Name: "b"}, {Value: 2, Name: "c"}, {Value: 1, Name: "d"}}
+		slices.SortStableBy(slice, func(x, y item) int { return x.Value - y.Value })
+		a.Assert(slices.Map(slice, func(x item) string { return x.Name }), qt.DeepEquals, []string{"b", "d", "a", "c"})
+	})
 }
 
 func TestIsSortedBy(t *testing.T) {
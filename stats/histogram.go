@@ -0,0 +1,94 @@
+package stats
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-board/std/constraints"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/iter/collector"
+)
+
+// Histogram counts elements into buckets separated by ascending upper bounds.
+//
+// Given bounds b0 < b1 < ... < bn, bucket 0 counts x < b0, bucket i counts
+// b(i-1) <= x < bi, and the last bucket counts x >= bn.
+type Histogram[N constraints.Real] struct {
+	bounds []float64
+	counts []int
+}
+
+var _ collector.Collector[int, *Histogram[int]] = (*Histogram[int])(nil)
+
+// NewHistogram creates an empty [Histogram], bounds are sorted in place.
+//
+// Example:
+//
+//	stats.NewHistogram[int](10, 100) => buckets: (-inf, 10), [10, 100), [100, +inf)
+func NewHistogram[N constraints.Real](bounds ...float64) *Histogram[N] {
+	sort.Float64s(bounds)
+	return &Histogram[N]{bounds: bounds, counts: make([]int, len(bounds)+1)}
+}
+
+// NewLinearHistogram creates an empty [Histogram] with n buckets of equal width
+// between start and end, plus one underflow and one overflow bucket.
+func NewLinearHistogram[N constraints.Real](start, end float64, n int) *Histogram[N] {
+	if n < 1 {
+		n = 1
+	}
+	bounds := make([]float64, n+1)
+	width := (end - start) / float64(n)
+	for i := range bounds {
+		bounds[i] = start + float64(i)*width
+	}
+	return NewHistogram[N](bounds...)
+}
+
+// ToHistogram creates a [collector.Collector] that counts elements into a [Histogram].
+func ToHistogram[N constraints.Real](bounds ...float64) collector.Collector[N, *Histogram[N]] {
+	return NewHistogram[N](bounds...)
+}
+
+// Add counts x into its bucket.
+func (h *Histogram[N]) Add(x N) {
+	f := float64(x)
+	h.counts[sort.Search(len(h.bounds), func(i int) bool { return h.bounds[i] > f })]++
+}
+
+// Bounds returns the upper bounds of buckets.
+func (h *Histogram[N]) Bounds() []float64 { return h.bounds }
+
+// Counts returns the count of each bucket, it has one more element than [Histogram.Bounds].
+func (h *Histogram[N]) Counts() []int { return h.counts }
+
+// Bucket returns the lower bound, upper bound and count of the i-th bucket.
+//
+// The lower bound of the first bucket is -Inf, the upper bound of the last bucket is +Inf.
+func (h *Histogram[N]) Bucket(i int) (lo float64, hi float64, count int) {
+	lo, hi = math.Inf(-1), math.Inf(+1)
+	if i > 0 {
+		lo = h.bounds[i-1]
+	}
+	if i < len(h.bounds) {
+		hi = h.bounds[i]
+	}
+	return lo, hi, h.counts[i]
+}
+
+// Total returns the number of counted elements.
+func (h *Histogram[N]) Total() int {
+	total := 0
+	for _, c := range h.counts {
+		total += c
+	}
+	return total
+}
+
+// Collect implements [collector.Collector].
+func (h *Histogram[N]) Collect(x N) { h.Add(x) }
+
+// CollectSeq implements [collector.Collector].
+func (h *Histogram[N]) CollectSeq(s iter.Seq[N]) { iter.ForEach(s, h.Add) }
+
+// Finish implements [collector.Collector], returns the histogram itself.
+func (h *Histogram[N]) Finish() *Histogram[N] { return h }
@@ -0,0 +1,128 @@
+package stats
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-board/std/constraints"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/iter/collector"
+)
+
+// Quantile estimates a single p-quantile of a stream in constant memory,
+// using the P² algorithm by Jain and Chlamtac.
+//
+// The estimate is exact for up to five elements, and converges to the true
+// quantile for larger, non-pathological inputs.
+type Quantile[N constraints.Real] struct {
+	p     float64
+	count int
+	q     [5]float64 // marker heights
+	n     [5]float64 // marker positions
+	np    [5]float64 // desired marker positions
+	dn    [5]float64 // desired position increments
+}
+
+var _ collector.Collector[int, float64] = (*Quantile[int])(nil)
+
+// NewQuantile creates a [Quantile] estimator for p, p is clamped to range [0, 1].
+func NewQuantile[N constraints.Real](p float64) *Quantile[N] {
+	p = math.Max(0, math.Min(1, p))
+	return &Quantile[N]{p: p, dn: [5]float64{0, p / 2, p, (1 + p) / 2, 1}}
+}
+
+// NewMedian creates a [Quantile] estimator for the median.
+func NewMedian[N constraints.Real]() *Quantile[N] { return NewQuantile[N](0.5) }
+
+// ToQuantile creates a [collector.Collector] that estimates the p-quantile using [Quantile].
+func ToQuantile[N constraints.Real](p float64) collector.Collector[N, float64] {
+	return NewQuantile[N](p)
+}
+
+// ToMedian creates a [collector.Collector] that estimates the median using [Quantile].
+func ToMedian[N constraints.Real]() collector.Collector[N, float64] { return NewMedian[N]() }
+
+// Add accumulates x.
+func (e *Quantile[N]) Add(x N) {
+	f := float64(x)
+	if e.count < 5 {
+		e.q[e.count] = f
+		e.count++
+		if e.count == 5 {
+			sort.Float64s(e.q[:])
+			p := e.p
+			e.n = [5]float64{1, 2, 3, 4, 5}
+			e.np = [5]float64{1, 1 + 2*p, 1 + 4*p, 3 + 2*p, 5}
+		}
+		return
+	}
+	e.count++
+
+	var k int
+	switch {
+	case f < e.q[0]:
+		e.q[0] = f
+		k = 0
+	case f >= e.q[4]:
+		e.q[4] = f
+		k = 3
+	default:
+		for k = 0; k < 3 && f >= e.q[k+1]; k++ {
+		}
+	}
+	for i := k + 1; i < 5; i++ {
+		e.n[i]++
+	}
+	for i := range e.np {
+		e.np[i] += e.dn[i]
+	}
+	for i := 1; i < 4; i++ {
+		d := e.np[i] - e.n[i]
+		if (d >= 1 && e.n[i+1]-e.n[i] > 1) || (d <= -1 && e.n[i-1]-e.n[i] < -1) {
+			d = math.Copysign(1, d)
+			q := e.parabolic(i, d)
+			if e.q[i-1] < q && q < e.q[i+1] {
+				e.q[i] = q
+			} else {
+				e.q[i] = e.linear(i, d)
+			}
+			e.n[i] += d
+		}
+	}
+}
+
+func (e *Quantile[N]) parabolic(i int, d float64) float64 {
+	q, n := e.q, e.n
+	return q[i] + d/(n[i+1]-n[i-1])*((n[i]-n[i-1]+d)*(q[i+1]-q[i])/(n[i+1]-n[i])+(n[i+1]-n[i]-d)*(q[i]-q[i-1])/(n[i]-n[i-1]))
+}
+
+func (e *Quantile[N]) linear(i int, d float64) float64 {
+	j := i + int(d)
+	return e.q[i] + d*(e.q[j]-e.q[i])/(e.n[j]-e.n[i])
+}
+
+// Count returns the number of accumulated elements.
+func (e *Quantile[N]) Count() int { return e.count }
+
+// Value returns the current estimate, or NaN if empty.
+func (e *Quantile[N]) Value() float64 {
+	if e.count == 0 {
+		return math.NaN()
+	}
+	if e.count < 5 {
+		xs := make([]float64, e.count)
+		copy(xs, e.q[:e.count])
+		sort.Float64s(xs)
+		return percentile(xs, e.p)
+	}
+	return e.q[2]
+}
+
+// Collect implements [collector.Collector].
+func (e *Quantile[N]) Collect(x N) { e.Add(x) }
+
+// CollectSeq implements [collector.Collector].
+func (e *Quantile[N]) CollectSeq(s iter.Seq[N]) { iter.ForEach(s, e.Add) }
+
+// Finish implements [collector.Collector], returns the current estimate.
+func (e *Quantile[N]) Finish() float64 { return e.Value() }
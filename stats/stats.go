@@ -0,0 +1,266 @@
+// Package stats provides numerically stable statistical aggregations over [iter.Seq].
+//
+// Every accumulator in this package implements [collector.Collector],
+// so it can be used with [collector.Collect] as well as fed element by element.
+package stats
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-board/std/constraints"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/iter/collector"
+)
+
+// kahan is a compensated summation accumulator, using Neumaier's variant of Kahan summation.
+type kahan struct{ sum, c float64 }
+
+func (k *kahan) add(x float64) {
+	t := k.sum + x
+	if math.Abs(k.sum) >= math.Abs(x) {
+		k.c += (k.sum - t) + x
+	} else {
+		k.c += (x - t) + k.sum
+	}
+	k.sum = t
+}
+
+func (k *kahan) value() float64 { return k.sum + k.c }
+
+// Moments accumulates count, sum, min, max, mean and variance in a single pass.
+//
+// Sum uses compensated summation, mean and variance use Welford's online algorithm,
+// so both stay accurate on long sequences.
+//
+// The zero value is an empty accumulator ready to use.
+type Moments[N constraints.Real] struct {
+	n        int
+	sum      kahan
+	mean, m2 float64
+	min, max N
+}
+
+var _ collector.Collector[int, *Moments[int]] = (*Moments[int])(nil)
+
+// NewMoments creates an empty [Moments].
+func NewMoments[N constraints.Real]() *Moments[N] { return &Moments[N]{} }
+
+// Add accumulates x.
+func (m *Moments[N]) Add(x N) {
+	if m.n == 0 || x < m.min {
+		m.min = x
+	}
+	if m.n == 0 || x > m.max {
+		m.max = x
+	}
+	m.n++
+	f := float64(x)
+	m.sum.add(f)
+	delta := f - m.mean
+	m.mean += delta / float64(m.n)
+	m.m2 += delta * (f - m.mean)
+}
+
+// Merge accumulates all elements accumulated by o,
+// the result is the same as accumulating both inputs in a single [Moments].
+func (m *Moments[N]) Merge(o *Moments[N]) {
+	if o.n == 0 {
+		return
+	}
+	if m.n == 0 {
+		*m = *o
+		return
+	}
+	n := m.n + o.n
+	delta := o.mean - m.mean
+	m.mean += delta * float64(o.n) / float64(n)
+	m.m2 += o.m2 + delta*delta*float64(m.n)*float64(o.n)/float64(n)
+	m.sum.add(o.sum.sum)
+	m.sum.add(o.sum.c)
+	if o.min < m.min {
+		m.min = o.min
+	}
+	if o.max > m.max {
+		m.max = o.max
+	}
+	m.n = n
+}
+
+// Count returns the number of accumulated elements.
+func (m *Moments[N]) Count() int { return m.n }
+
+// Sum returns the sum of accumulated elements.
+func (m *Moments[N]) Sum() float64 { return m.sum.value() }
+
+// Min returns the minimum accumulated element, or zero if empty.
+func (m *Moments[N]) Min() N { return m.min }
+
+// Max returns the maximum accumulated element, or zero if empty.
+func (m *Moments[N]) Max() N { return m.max }
+
+// Mean returns the arithmetic mean, or NaN if empty.
+func (m *Moments[N]) Mean() float64 {
+	if m.n == 0 {
+		return math.NaN()
+	}
+	return m.mean
+}
+
+// Variance returns the population variance, or NaN if empty.
+func (m *Moments[N]) Variance() float64 {
+	if m.n == 0 {
+		return math.NaN()
+	}
+	return m.m2 / float64(m.n)
+}
+
+// SampleVariance returns the unbiased sample variance, or NaN if less than two elements.
+func (m *Moments[N]) SampleVariance() float64 {
+	if m.n < 2 {
+		return math.NaN()
+	}
+	return m.m2 / float64(m.n-1)
+}
+
+// StdDev returns the population standard deviation, or NaN if empty.
+func (m *Moments[N]) StdDev() float64 { return math.Sqrt(m.Variance()) }
+
+// SampleStdDev returns the sample standard deviation, or NaN if less than two elements.
+func (m *Moments[N]) SampleStdDev() float64 { return math.Sqrt(m.SampleVariance()) }
+
+// Collect implements [collector.Collector].
+func (m *Moments[N]) Collect(x N) { m.Add(x) }
+
+// CollectSeq implements [collector.Collector].
+func (m *Moments[N]) CollectSeq(s iter.Seq[N]) { iter.ForEach(s, m.Add) }
+
+// Finish implements [collector.Collector], returns the accumulator itself.
+func (m *Moments[N]) Finish() *Moments[N] { return m }
+
+// ToMoments creates a [collector.Collector] that accumulates a [Moments].
+func ToMoments[N constraints.Real]() collector.Collector[N, *Moments[N]] { return NewMoments[N]() }
+
+// momentsCollector extracts a single statistic from [Moments] on finish.
+type momentsCollector[N constraints.Real, R any] struct {
+	*Moments[N]
+	f func(*Moments[N]) R
+}
+
+func (c momentsCollector[N, R]) Finish() R { return c.f(c.Moments) }
+
+func toMoments[N constraints.Real, R any](f func(*Moments[N]) R) collector.Collector[N, R] {
+	return momentsCollector[N, R]{Moments: NewMoments[N](), f: f}
+}
+
+// ToSum creates a [collector.Collector] that computes the compensated sum.
+func ToSum[N constraints.Real]() collector.Collector[N, float64] {
+	return toMoments((*Moments[N]).Sum)
+}
+
+// ToMean creates a [collector.Collector] that computes the arithmetic mean, NaN if empty.
+func ToMean[N constraints.Real]() collector.Collector[N, float64] {
+	return toMoments((*Moments[N]).Mean)
+}
+
+// ToVariance creates a [collector.Collector] that computes the population variance, NaN if empty.
+func ToVariance[N constraints.Real]() collector.Collector[N, float64] {
+	return toMoments((*Moments[N]).Variance)
+}
+
+// ToStdDev creates a [collector.Collector] that computes the population standard deviation, NaN if empty.
+func ToStdDev[N constraints.Real]() collector.Collector[N, float64] {
+	return toMoments((*Moments[N]).StdDev)
+}
+
+func moments[N constraints.Real](s iter.Seq[N]) *Moments[N] {
+	return collector.Collect(s, ToMoments[N]())
+}
+
+// Sum returns the sum of all elements in [iter.Seq] using compensated summation.
+//
+// Example:
+//
+//	stats.Sum(seq(0.1, 0.2, 0.3)) => 0.6
+func Sum[N constraints.Real](s iter.Seq[N]) float64 {
+	return moments(s).Sum()
+}
+
+// Mean returns the arithmetic mean of all elements in [iter.Seq],
+// the second return value is false if [iter.Seq] is empty.
+//
+// Example:
+//
+//	stats.Mean(seq(1,2,3,4)) => 2.5, true
+//	stats.Mean(seq[int]())   => 0, false
+func Mean[N constraints.Real](s iter.Seq[N]) (float64, bool) {
+	m := moments(s)
+	if m.Count() == 0 {
+		return 0, false
+	}
+	return m.Mean(), true
+}
+
+// Variance returns the population variance of all elements in [iter.Seq],
+// the second return value is false if [iter.Seq] is empty.
+//
+// Example:
+//
+//	stats.Variance(seq(2,4,4,4,5,5,7,9)) => 4, true
+func Variance[N constraints.Real](s iter.Seq[N]) (float64, bool) {
+	m := moments(s)
+	if m.Count() == 0 {
+		return 0, false
+	}
+	return m.Variance(), true
+}
+
+// StdDev returns the population standard deviation of all elements in [iter.Seq],
+// the second return value is false if [iter.Seq] is empty.
+//
+// Example:
+//
+//	stats.StdDev(seq(2,4,4,4,5,5,7,9)) => 2, true
+func StdDev[N constraints.Real](s iter.Seq[N]) (float64, bool) {
+	v, ok := Variance(s)
+	return math.Sqrt(v), ok
+}
+
+// Percentile returns the exact p-quantile of all elements in [iter.Seq],
+// interpolating linearly between the closest ranks, p is in range [0, 1].
+//
+// It buffers and sorts the whole [iter.Seq], use [Quantile] for an approximate streaming version.
+// The second return value is false if [iter.Seq] is empty or p is NaN or out of range.
+//
+// Example:
+//
+//	stats.Percentile(seq(1,2,3,4), 0.5) => 2.5, true
+func Percentile[N constraints.Real](s iter.Seq[N], p float64) (float64, bool) {
+	if !(p >= 0 && p <= 1) {
+		return 0, false
+	}
+	xs := iter.Fold(s, make([]float64, 0), func(acc []float64, x N) []float64 { return append(acc, float64(x)) })
+	if len(xs) == 0 {
+		return 0, false
+	}
+	sort.Float64s(xs)
+	return percentile(xs, p), true
+}
+
+// percentile computes the p-quantile of sorted xs.
+func percentile(xs []float64, p float64) float64 {
+	rank := p * float64(len(xs)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return xs[lo] + (rank-float64(lo))*(xs[hi]-xs[lo])
+}
+
+// Median returns the median of all elements in [iter.Seq],
+// the second return value is false if [iter.Seq] is empty.
+//
+// Example:
+//
+//	stats.Median(seq(3,1,2)) => 2, true
+func Median[N constraints.Real](s iter.Seq[N]) (float64, bool) {
+	return Percentile(s, 0.5)
+}
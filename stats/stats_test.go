@@ -0,0 +1,114 @@
+package stats_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/iter/collector"
+	"github.com/go-board/std/iter/source"
+	"github.com/go-board/std/stats"
+)
+
+func seq[E any](elems ...E) iter.Seq[E] { return source.Variadic(elems...) }
+
+func approx(t *testing.T, got, want, eps float64) {
+	t.Helper()
+	qt.Assert(t, math.Abs(got-want) <= eps, qt.IsTrue, qt.Commentf("got %v, want %v", got, want))
+}
+
+func TestSum(t *testing.T) {
+	qt.Assert(t, stats.Sum(seq(1, 2, 3)), qt.Equals, 6.0)
+	qt.Assert(t, stats.Sum(seq[int]()), qt.Equals, 0.0)
+	// naive summation loses the small terms.
+	x := stats.Sum(iter.Chain(seq(1e16), source.RepeatTimes(1.0, 1000)))
+	qt.Assert(t, x, qt.Equals, 1e16+1000)
+}
+
+func TestMoments(t *testing.T) {
+	data := seq(2, 4, 4, 4, 5, 5, 7, 9)
+	m, ok := stats.Mean(data)
+	qt.Assert(t, ok, qt.IsTrue)
+	qt.Assert(t, m, qt.Equals, 5.0)
+	v, _ := stats.Variance(data)
+	qt.Assert(t, v, qt.Equals, 4.0)
+	sd, _ := stats.StdDev(data)
+	qt.Assert(t, sd, qt.Equals, 2.0)
+	_, ok = stats.Mean(seq[int]())
+	qt.Assert(t, ok, qt.IsFalse)
+
+	acc := collector.Collect(data, stats.ToMoments[int]())
+	qt.Assert(t, acc.Count(), qt.Equals, 8)
+	qt.Assert(t, acc.Min(), qt.Equals, 2)
+	qt.Assert(t, acc.Max(), qt.Equals, 9)
+	approx(t, acc.SampleVariance(), 32.0/7, 1e-12)
+	qt.Assert(t, math.IsNaN(stats.NewMoments[int]().Mean()), qt.IsTrue)
+
+	t.Run("merge", func(t *testing.T) {
+		a := collector.Collect(seq(2, 4, 4, 4), stats.ToMoments[int]())
+		b := collector.Collect(seq(5, 5, 7, 9), stats.ToMoments[int]())
+		a.Merge(b)
+		qt.Assert(t, a.Count(), qt.Equals, 8)
+		approx(t, a.Mean(), 5, 1e-12)
+		approx(t, a.Variance(), 4, 1e-12)
+		qt.Assert(t, a.Max(), qt.Equals, 9)
+	})
+	t.Run("collectors", func(t *testing.T) {
+		qt.Assert(t, collector.Collect(data, stats.ToMean[int]()), qt.Equals, 5.0)
+		qt.Assert(t, collector.Collect(data, stats.ToStdDev[int]()), qt.Equals, 2.0)
+		qt.Assert(t, collector.Collect(data, stats.ToVariance[int]()), qt.Equals, 4.0)
+		qt.Assert(t, collector.Collect(data, stats.ToSum[int]()), qt.Equals, 40.0)
+	})
+}
+
+func TestPercentile(t *testing.T) {
+	x, ok := stats.Percentile(seq(1, 2, 3, 4), 0.5)
+	qt.Assert(t, ok, qt.IsTrue)
+	qt.Assert(t, x, qt.Equals, 2.5)
+	y, _ := stats.Median(seq(3, 1, 2))
+	qt.Assert(t, y, qt.Equals, 2.0)
+	z, _ := stats.Percentile(seq(1, 2, 3, 4, 5), 1)
+	qt.Assert(t, z, qt.Equals, 5.0)
+	_, ok = stats.Percentile(seq(1), 2)
+	qt.Assert(t, ok, qt.IsFalse)
+	_, ok = stats.Percentile(seq(1, 2), math.NaN())
+	qt.Assert(t, ok, qt.IsFalse)
+	_, ok = stats.Median(seq[int]())
+	qt.Assert(t, ok, qt.IsFalse)
+}
+
+func TestQuantile(t *testing.T) {
+	t.Run("small", func(t *testing.T) {
+		qt.Assert(t, collector.Collect(seq(3, 1, 2), stats.ToMedian[int]()), qt.Equals, 2.0)
+		qt.Assert(t, math.IsNaN(stats.NewMedian[int]().Value()), qt.IsTrue)
+	})
+	t.Run("uniform", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		p50, p90 := stats.NewQuantile[float64](0.5), stats.NewQuantile[float64](0.9)
+		for i := 0; i < 100000; i++ {
+			x := r.Float64() * 100
+			p50.Add(x)
+			p90.Add(x)
+		}
+		approx(t, p50.Value(), 50, 1)
+		approx(t, p90.Value(), 90, 1)
+	})
+}
+
+func TestHistogram(t *testing.T) {
+	h := collector.Collect(seq(1, 5, 10, 50, 100, 1000), stats.ToHistogram[int](10, 100))
+	qt.Assert(t, h.Counts(), qt.DeepEquals, []int{2, 2, 2})
+	qt.Assert(t, h.Total(), qt.Equals, 6)
+	lo, hi, n := h.Bucket(1)
+	qt.Assert(t, lo, qt.Equals, 10.0)
+	qt.Assert(t, hi, qt.Equals, 100.0)
+	qt.Assert(t, n, qt.Equals, 2)
+	lo, _, _ = h.Bucket(0)
+	qt.Assert(t, math.IsInf(lo, -1), qt.IsTrue)
+
+	l := stats.NewLinearHistogram[float64](0, 3, 3)
+	l.CollectSeq(seq(0.5, 1.5, 2.5, -1, 3))
+	qt.Assert(t, l.Counts(), qt.DeepEquals, []int{1, 1, 1, 1, 1})
+}
@@ -0,0 +1,79 @@
+package sync
+
+import (
+	"sync/atomic"
+)
+
+// Atomic is a typed value which can be loaded and stored atomically.
+//
+// The zero value holds the zero value of T.
+type Atomic[T any] struct{ inner atomic.Pointer[T] }
+
+// NewAtomic creates an [Atomic] holding v.
+func NewAtomic[T any](v T) *Atomic[T] {
+	a := new(Atomic[T])
+	a.Store(v)
+	return a
+}
+
+// Load returns the current value.
+func (self *Atomic[T]) Load() T {
+	if p := self.inner.Load(); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// Store sets the value.
+func (self *Atomic[T]) Store(v T) { self.inner.Store(&v) }
+
+// Swap sets the value and returns the previous one.
+func (self *Atomic[T]) Swap(v T) T {
+	if p := self.inner.Swap(&v); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// CompareAndSwapFunc sets the value to new if the current value equals to old using eq,
+// and reports whether it's set.
+func (self *Atomic[T]) CompareAndSwapFunc(old, new T, eq func(T, T) bool) bool {
+	for {
+		p := self.inner.Load()
+		var cur T
+		if p != nil {
+			cur = *p
+		}
+		if !eq(cur, old) {
+			return false
+		}
+		if self.inner.CompareAndSwap(p, &new) {
+			return true
+		}
+	}
+}
+
+// Update sets the value to f(current) atomically, and returns the new value.
+//
+// f may be called more than once under contention, so it must be free of side effects.
+func (self *Atomic[T]) Update(f func(T) T) T {
+	for {
+		p := self.inner.Load()
+		var cur T
+		if p != nil {
+			cur = *p
+		}
+		next := f(cur)
+		if self.inner.CompareAndSwap(p, &next) {
+			return next
+		}
+	}
+}
+
+// CompareAndSwap sets the value of a to new if the current value equals to old,
+// and reports whether it's set.
+func CompareAndSwap[T comparable](a *Atomic[T], old, new T) bool {
+	return a.CompareAndSwapFunc(old, new, func(x, y T) bool { return x == y })
+}
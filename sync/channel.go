@@ -34,6 +34,12 @@ func (cs *ChannelSender[T]) Close() {
 	cs.closed.Store(true)
 }
 
+// Chan returns the underlying channel, to use the sender in a select or with
+// the channel combinators like [Merge].
+//
+// Close doesn't close the channel, so consumers of it stop on their context.
+func (cs *ChannelSender[T]) Chan() chan<- T { return cs.inner }
+
 type ChannelReceiver[T any] struct {
 	inner  <-chan T
 	closed *atomic.Bool
@@ -65,6 +71,10 @@ func (r *ChannelReceiver[T]) Range(f func(T)) {
 	}
 }
 
+// Chan returns the underlying channel, to use the receiver in a select or with
+// the channel combinators like [Merge] and [Batch].
+func (r *ChannelReceiver[T]) Chan() <-chan T { return r.inner }
+
 func BufferedChannel[T any](n int) (sender *ChannelSender[T], receiver *ChannelReceiver[T]) {
 	channel := make(chan T, n)
 	closed := &atomic.Bool{}
@@ -0,0 +1,265 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/go-board/std/iter"
+	"github.com/go-board/std/optional"
+	"github.com/go-board/std/result"
+)
+
+// ErrNoFutures is the error of [Any] and [Race] called without futures.
+var ErrNoFutures = errors.New("sync: no futures")
+
+// Future is the result of an asynchronous computation, which becomes available once it's done.
+//
+// Combinators like [Then] and [All] watch their inputs on goroutines which exit once the
+// inputs are done, so a future which never completes keeps them alive.
+type Future[T any] struct {
+	once sync.Once
+	done chan struct{}
+	res  result.Result[T]
+}
+
+func newFuture[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+// complete sets the result and wakes up waiters if it's not done yet,
+// and reports whether the result is set.
+func (self *Future[T]) complete(r result.Result[T]) bool {
+	completed := false
+	self.once.Do(func() {
+		self.res = r
+		close(self.done)
+		completed = true
+	})
+	return completed
+}
+
+// Done returns a channel which is closed once the result is available.
+func (self *Future[T]) Done() <-chan struct{} { return self.done }
+
+// Await waits for the result, returns ctx.Err() as an error if ctx is done first.
+func (self *Future[T]) Await(ctx context.Context) result.Result[T] {
+	select {
+	case <-self.done:
+		return self.res
+	case <-ctx.Done():
+		return result.Err[T](ctx.Err())
+	}
+}
+
+// Peek returns the result if it's available, without waiting.
+func (self *Future[T]) Peek() optional.Optional[result.Result[T]] {
+	select {
+	case <-self.done:
+		return optional.Some(self.res)
+	default:
+		return optional.None[result.Result[T]]()
+	}
+}
+
+// Promise is the writing side of a [Future], it completes the future from another goroutine.
+//
+// Example:
+//
+//	p := NewPromise[int]()
+//	go func() { p.Resolve(42) }()
+//	p.Future().Await(ctx) // Ok(42)
+type Promise[T any] struct{ fut *Future[T] }
+
+// NewPromise creates a [Promise] with a pending future.
+func NewPromise[T any]() *Promise[T] { return &Promise[T]{fut: newFuture[T]()} }
+
+// Future returns the future completed by the promise.
+func (self *Promise[T]) Future() *Future[T] { return self.fut }
+
+// Complete completes the future with r, and reports whether it's completed by this call.
+// Only the first completion takes effect.
+func (self *Promise[T]) Complete(r result.Result[T]) bool { return self.fut.complete(r) }
+
+// Resolve completes the future with value v, see [Promise.Complete].
+func (self *Promise[T]) Resolve(v T) bool { return self.Complete(result.Ok(v)) }
+
+// Reject completes the future with err, see [Promise.Complete].
+func (self *Promise[T]) Reject(err error) bool { return self.Complete(result.Err[T](err)) }
+
+// Ready returns a future which is already completed with r.
+func Ready[T any](r result.Result[T]) *Future[T] {
+	fut := newFuture[T]()
+	fut.complete(r)
+	return fut
+}
+
+// Async runs f on a new goroutine and returns the future of its result.
+//
+// If f panics, the future results in a [*PanicError].
+func Async[T any](ctx context.Context, f func(ctx context.Context) result.Result[T]) *Future[T] {
+	fut := newFuture[T]()
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				fut.complete(result.Err[T](&PanicError{Value: p, Stack: debug.Stack()}))
+			}
+		}()
+		fut.complete(f(ctx))
+	}()
+	return fut
+}
+
+// Then returns a future of f applied to the value of fut,
+// the error of fut is passed through without calling f.
+//
+// If f panics, the future results in a [*PanicError].
+func Then[T, U any](fut *Future[T], f func(T) result.Result[U]) *Future[U] {
+	next := newFuture[U]()
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				next.complete(result.Err[U](&PanicError{Value: p, Stack: debug.Stack()}))
+			}
+		}()
+		<-fut.done
+		if fut.res.IsErr() {
+			next.complete(result.Err[U](fut.res.Error()))
+			return
+		}
+		next.complete(f(fut.res.Value()))
+	}()
+	return next
+}
+
+// MapFuture returns a future of f applied to the value of fut,
+// the error of fut is passed through without calling f, a panic of f is recovered as in [Then].
+func MapFuture[T, U any](fut *Future[T], f func(T) U) *Future[U] {
+	return Then(fut, func(v T) result.Result[U] { return result.Ok(f(v)) })
+}
+
+// Timeout returns a future of the result of fut, which results in [context.DeadlineExceeded]
+// if fut is not done within d.
+func Timeout[T any](fut *Future[T], d time.Duration) *Future[T] {
+	next := newFuture[T]()
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-fut.done:
+			next.complete(fut.res)
+		case <-timer.C:
+			next.complete(result.Err[T](context.DeadlineExceeded))
+		}
+	}()
+	return next
+}
+
+// All returns a future of the values of all futs in order,
+// it results in the first error as soon as any of futs fails.
+func All[T any](futs ...*Future[T]) *Future[[]T] {
+	next := newFuture[[]T]()
+	if len(futs) == 0 {
+		next.complete(result.Ok([]T{}))
+		return next
+	}
+	values := make([]T, len(futs))
+	var wg sync.WaitGroup
+	wg.Add(len(futs))
+	for i, fut := range futs {
+		go func(i int, fut *Future[T]) {
+			defer wg.Done()
+			<-fut.done
+			if fut.res.IsErr() {
+				next.complete(result.Err[[]T](fut.res.Error()))
+				return
+			}
+			values[i] = fut.res.Value()
+		}(i, fut)
+	}
+	go func() {
+		wg.Wait()
+		next.complete(result.Ok(values))
+	}()
+	return next
+}
+
+// Any returns a future of the first value among futs,
+// if all of them fail it results in an error wrapping the last error.
+func Any[T any](futs ...*Future[T]) *Future[T] {
+	next := newFuture[T]()
+	if len(futs) == 0 {
+		next.complete(result.Err[T](ErrNoFutures))
+		return next
+	}
+	var mu sync.Mutex
+	failed := 0
+	for _, fut := range futs {
+		go func(fut *Future[T]) {
+			<-fut.done
+			if fut.res.IsOk() {
+				next.complete(fut.res)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if failed++; failed == len(futs) {
+				next.complete(result.Err[T](fmt.Errorf("sync: all %d futures failed, last error: %w", len(futs), fut.res.Error())))
+			}
+		}(fut)
+	}
+	return next
+}
+
+// Race returns a future of the result of whichever of futs is done first, value or error.
+func Race[T any](futs ...*Future[T]) *Future[T] {
+	next := newFuture[T]()
+	if len(futs) == 0 {
+		next.complete(result.Err[T](ErrNoFutures))
+		return next
+	}
+	for _, fut := range futs {
+		go func(fut *Future[T]) {
+			<-fut.done
+			next.complete(fut.res)
+		}(fut)
+	}
+	return next
+}
+
+// Completed returns an iterator over the index and the result of each of futs,
+// in the order they complete.
+//
+// Iteration stops early if ctx is done, futures not yielded by then are skipped.
+//
+// Example:
+//
+//	Completed(ctx, futs...)(func(i int, r result.Result[T]) bool {
+//		fmt.Println(i, r)
+//		return true
+//	})
+func Completed[T any](ctx context.Context, futs ...*Future[T]) iter.Seq2[int, result.Result[T]] {
+	return func(yield func(int, result.Result[T]) bool) {
+		// buffered, so watchers never block after iteration stops.
+		ch := make(chan int, len(futs))
+		for i, fut := range futs {
+			go func(i int, fut *Future[T]) {
+				<-fut.done
+				ch <- i
+			}(i, fut)
+		}
+		for range futs {
+			select {
+			case i := <-ch:
+				if !yield(i, futs[i].res) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
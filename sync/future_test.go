@@ -0,0 +1,146 @@
+package sync_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/result"
+	xsync "github.com/go-board/std/sync"
+)
+
+var errFuture = errors.New("future failed")
+
+func TestPromise(t *testing.T) {
+	p := xsync.NewPromise[int]()
+	fut := p.Future()
+	qt.Assert(t, fut.Peek().IsNone(), qt.IsTrue)
+	go func() { p.Resolve(42) }()
+	qt.Assert(t, fut.Await(context.Background()).Value(), qt.Equals, 42)
+	qt.Assert(t, p.Reject(errFuture), qt.IsFalse)
+	qt.Assert(t, fut.Peek().Value().Value(), qt.Equals, 42)
+}
+
+func TestFuture_AwaitCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := xsync.NewPromise[int]().Future().Await(ctx)
+	qt.Assert(t, r.Error(), qt.Equals, context.Canceled)
+}
+
+func TestAsync(t *testing.T) {
+	checkGoroutines(t)
+	fut := xsync.Async(context.Background(), func(ctx context.Context) result.Result[int] { return result.Ok(1) })
+	qt.Assert(t, fut.Await(context.Background()).Value(), qt.Equals, 1)
+
+	fut = xsync.Async(context.Background(), func(ctx context.Context) result.Result[int] { panic("boom") })
+	var pe *xsync.PanicError
+	qt.Assert(t, errors.As(fut.Await(context.Background()).Error(), &pe), qt.IsTrue)
+}
+
+func TestThen(t *testing.T) {
+	checkGoroutines(t)
+	fut := xsync.MapFuture(xsync.Ready(result.Ok(2)), func(x int) string { return string(rune('a' + x)) })
+	qt.Assert(t, fut.Await(context.Background()).Value(), qt.Equals, "c")
+
+	called := false
+	failed := xsync.Then(xsync.Ready(result.Err[int](errFuture)), func(x int) result.Result[int] {
+		called = true
+		return result.Ok(x)
+	})
+	qt.Assert(t, failed.Await(context.Background()).Error(), qt.Equals, errFuture)
+	qt.Assert(t, called, qt.IsFalse)
+}
+
+func TestThen_Panic(t *testing.T) {
+	checkGoroutines(t)
+	ctx := context.Background()
+	fut := xsync.MapFuture(xsync.Ready(result.Ok(0)), func(x int) int { return 1 / x })
+	err := fut.Await(ctx).Error()
+	var pe *xsync.PanicError
+	qt.Assert(t, errors.As(err, &pe), qt.IsTrue)
+	qt.Assert(t, pe.Value, qt.ErrorMatches, ".*divide by zero")
+}
+
+func TestTimeout(t *testing.T) {
+	checkGoroutines(t)
+	p := xsync.NewPromise[int]()
+	r := xsync.Timeout(p.Future(), 10*time.Millisecond).Await(context.Background())
+	qt.Assert(t, r.Error(), qt.Equals, context.DeadlineExceeded)
+	r = xsync.Timeout(xsync.Ready(result.Ok(1)), time.Minute).Await(context.Background())
+	qt.Assert(t, r.Value(), qt.Equals, 1)
+}
+
+func TestAll(t *testing.T) {
+	checkGoroutines(t)
+	ps := []*xsync.Promise[int]{xsync.NewPromise[int](), xsync.NewPromise[int](), xsync.NewPromise[int]()}
+	fut := xsync.All(ps[0].Future(), ps[1].Future(), ps[2].Future())
+	ps[2].Resolve(3)
+	ps[0].Resolve(1)
+	ps[1].Resolve(2)
+	qt.Assert(t, fut.Await(context.Background()).Value(), qt.DeepEquals, []int{1, 2, 3})
+
+	pending := xsync.NewPromise[int]()
+	failed := xsync.All(pending.Future(), xsync.Ready(result.Err[int](errFuture)))
+	qt.Assert(t, failed.Await(context.Background()).Error(), qt.Equals, errFuture)
+	pending.Resolve(0)
+
+	qt.Assert(t, xsync.All[int]().Await(context.Background()).Value(), qt.HasLen, 0)
+}
+
+func TestAny(t *testing.T) {
+	checkGoroutines(t)
+	r := xsync.Any(xsync.Ready(result.Err[int](errFuture)), xsync.Ready(result.Ok(2))).Await(context.Background())
+	qt.Assert(t, r.Value(), qt.Equals, 2)
+
+	r = xsync.Any(xsync.Ready(result.Err[int](errors.New("other"))), xsync.Ready(result.Err[int](errFuture))).Await(context.Background())
+	qt.Assert(t, r.IsErr(), qt.IsTrue)
+
+	qt.Assert(t, xsync.Any[int]().Await(context.Background()).Error(), qt.Equals, xsync.ErrNoFutures)
+}
+
+func TestRace(t *testing.T) {
+	checkGoroutines(t)
+	pending := xsync.NewPromise[int]()
+	r := xsync.Race(pending.Future(), xsync.Ready(result.Err[int](errFuture))).Await(context.Background())
+	qt.Assert(t, r.Error(), qt.Equals, errFuture)
+	pending.Resolve(0)
+	qt.Assert(t, xsync.Race[int]().Await(context.Background()).Error(), qt.Equals, xsync.ErrNoFutures)
+}
+
+func TestCompleted(t *testing.T) {
+	checkGoroutines(t)
+	ps := []*xsync.Promise[int]{xsync.NewPromise[int](), xsync.NewPromise[int](), xsync.NewPromise[int]()}
+	futs := []*xsync.Future[int]{ps[0].Future(), ps[1].Future(), ps[2].Future()}
+	go func() {
+		for _, i := range []int{2, 0, 1} {
+			ps[i].Resolve(i * 10)
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+	var order, values []int
+	xsync.Completed(context.Background(), futs...)(func(i int, r result.Result[int]) bool {
+		order = append(order, i)
+		values = append(values, r.Value())
+		return true
+	})
+	qt.Assert(t, order, qt.DeepEquals, []int{2, 0, 1})
+	sort.Ints(values)
+	qt.Assert(t, values, qt.DeepEquals, []int{0, 10, 20})
+}
+
+func TestCompleted_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := xsync.NewPromise[int]()
+	n := 0
+	xsync.Completed(ctx, xsync.Ready(result.Ok(1)), p.Future())(func(int, result.Result[int]) bool {
+		n++
+		cancel()
+		return true
+	})
+	qt.Assert(t, n, qt.Equals, 1)
+	p.Resolve(0)
+}
@@ -0,0 +1,95 @@
+package sync
+
+import (
+	"context"
+	"sync"
+)
+
+// Group runs tasks on goroutines and collects their typed results,
+// like errgroup but without the interface{} results plumbing.
+//
+// The first task returning an error cancels the context passed to all tasks.
+type Group[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	sem    chan struct{}
+
+	mu      sync.Mutex
+	results []T
+	err     error
+}
+
+// NewGroup creates a [Group] whose tasks run with a context derived from ctx,
+// at most limit tasks run at the same time, no limit if limit is not positive.
+func NewGroup[T any](ctx context.Context, limit int) *Group[T] {
+	g := &Group[T]{}
+	g.ctx, g.cancel = context.WithCancel(ctx)
+	if limit > 0 {
+		g.sem = make(chan struct{}, limit)
+	}
+	return g
+}
+
+// reserve reserves a slot for the result of a new task and returns its index.
+func (self *Group[T]) reserve() int {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	var zero T
+	self.results = append(self.results, zero)
+	return len(self.results) - 1
+}
+
+func (self *Group[T]) run(i int, f func(ctx context.Context) (T, error)) {
+	self.wg.Add(1)
+	go func() {
+		defer func() {
+			if self.sem != nil {
+				<-self.sem
+			}
+			self.wg.Done()
+		}()
+		v, err := f(self.ctx)
+		self.mu.Lock()
+		defer self.mu.Unlock()
+		if err != nil {
+			if self.err == nil {
+				self.err = err
+				self.cancel()
+			}
+			return
+		}
+		self.results[i] = v
+	}()
+}
+
+// Go runs f on a new goroutine, blocks until there is room if the limit is reached.
+func (self *Group[T]) Go(f func(ctx context.Context) (T, error)) {
+	if self.sem != nil {
+		self.sem <- struct{}{}
+	}
+	self.run(self.reserve(), f)
+}
+
+// TryGo runs f on a new goroutine if the limit is not reached, and reports whether it's started.
+func (self *Group[T]) TryGo(f func(ctx context.Context) (T, error)) bool {
+	if self.sem != nil {
+		select {
+		case self.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	self.run(self.reserve(), f)
+	return true
+}
+
+// Wait waits for all tasks, returns their results in the order they were started
+// and the first error if any. The result of a failed task is the zero value.
+func (self *Group[T]) Wait() ([]T, error) {
+	self.wg.Wait()
+	self.cancel()
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.results, self.err
+}
@@ -0,0 +1,52 @@
+//go:build !go1.20
+
+package sync
+
+import "sync"
+
+// Before go1.20, sync.Map has no compare-and-swap, read-modify-write operations
+// are serialized by a mutex, which makes them atomic with respect to each other,
+// but not to Insert and Delete.
+type mapLock = sync.Mutex
+
+func (self *Map[K, V]) swap(key K, v V) (any, bool) {
+	self.rmw.Lock()
+	defer self.rmw.Unlock()
+	prev, loaded := self.inner.Load(key)
+	self.inner.Store(key, &v)
+	return prev, loaded
+}
+
+func (self *Map[K, V]) compute(key K, f func(old V, loaded bool) (V, bool)) (V, bool) {
+	self.rmw.Lock()
+	defer self.rmw.Unlock()
+	old, loaded := self.cast(self.inner.Load(key))
+	v, keep := f(old, loaded)
+	switch {
+	case keep:
+		self.inner.Store(key, &v)
+	case loaded:
+		self.inner.Delete(key)
+	}
+	return v, keep
+}
+
+func compareAndSwap[K any, V comparable](m *Map[K, V], key K, old, new V) bool {
+	m.rmw.Lock()
+	defer m.rmw.Unlock()
+	if cur, ok := m.inner.Load(key); !ok || *cur.(*V) != old {
+		return false
+	}
+	m.inner.Store(key, &new)
+	return true
+}
+
+func compareAndDelete[K any, V comparable](m *Map[K, V], key K, old V) bool {
+	m.rmw.Lock()
+	defer m.rmw.Unlock()
+	if cur, ok := m.inner.Load(key); !ok || *cur.(*V) != old {
+		return false
+	}
+	m.inner.Delete(key)
+	return true
+}
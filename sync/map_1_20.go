@@ -0,0 +1,58 @@
+//go:build go1.20
+
+package sync
+
+// mapLock is unused since go1.20, every read-modify-write is a compare-and-swap loop.
+type mapLock struct{}
+
+func (self *Map[K, V]) swap(key K, v V) (any, bool) {
+	return self.inner.Swap(key, &v)
+}
+
+func (self *Map[K, V]) compute(key K, f func(old V, loaded bool) (V, bool)) (V, bool) {
+	for {
+		cur, loaded := self.inner.Load(key)
+		old, _ := self.cast(cur, loaded)
+		v, keep := f(old, loaded)
+		switch {
+		case !keep && !loaded:
+			return v, false
+		case !keep:
+			if self.inner.CompareAndDelete(key, cur) {
+				return v, false
+			}
+		case loaded:
+			if self.inner.CompareAndSwap(key, cur, &v) {
+				return v, true
+			}
+		default:
+			if _, raced := self.inner.LoadOrStore(key, &v); !raced {
+				return v, true
+			}
+		}
+	}
+}
+
+func compareAndSwap[K any, V comparable](m *Map[K, V], key K, old, new V) bool {
+	for {
+		cur, ok := m.inner.Load(key)
+		if !ok || *cur.(*V) != old {
+			return false
+		}
+		if m.inner.CompareAndSwap(key, cur, &new) {
+			return true
+		}
+	}
+}
+
+func compareAndDelete[K any, V comparable](m *Map[K, V], key K, old V) bool {
+	for {
+		cur, ok := m.inner.Load(key)
+		if !ok || *cur.(*V) != old {
+			return false
+		}
+		if m.inner.CompareAndDelete(key, cur) {
+			return true
+		}
+	}
+}
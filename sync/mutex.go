@@ -0,0 +1,104 @@
+package sync
+
+import (
+	"sync"
+)
+
+// Mutex guards a value with a mutual exclusion lock,
+// the value is only reachable while holding the lock.
+//
+// A Mutex must not be copied after first use.
+type Mutex[T any] struct {
+	mu  sync.Mutex
+	val T
+}
+
+// NewMutex creates a [Mutex] guarding v.
+func NewMutex[T any](v T) *Mutex[T] {
+	return &Mutex[T]{val: v}
+}
+
+// Lock calls f with the guarded value while holding the lock.
+//
+// The pointer must not escape f.
+func (self *Mutex[T]) Lock(f func(*T)) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	f(&self.val)
+}
+
+// TryLock calls f with the guarded value if the lock is acquired without blocking,
+// and reports whether f is called.
+func (self *Mutex[T]) TryLock(f func(*T)) bool {
+	if !self.mu.TryLock() {
+		return false
+	}
+	defer self.mu.Unlock()
+	f(&self.val)
+	return true
+}
+
+// WithLock calls f with the value guarded by m while holding the lock, and returns its result.
+func WithLock[T, R any](m *Mutex[T], f func(*T) R) (r R) {
+	m.Lock(func(v *T) { r = f(v) })
+	return
+}
+
+// RWMutex guards a value with a reader/writer lock,
+// the value is only reachable while holding the lock.
+//
+// A RWMutex must not be copied after first use.
+type RWMutex[T any] struct {
+	mu  sync.RWMutex
+	val T
+}
+
+// NewRWMutex creates a [RWMutex] guarding v.
+func NewRWMutex[T any](v T) *RWMutex[T] {
+	return &RWMutex[T]{val: v}
+}
+
+// Lock calls f with the guarded value while holding the write lock.
+//
+// The pointer must not escape f.
+func (self *RWMutex[T]) Lock(f func(*T)) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	f(&self.val)
+}
+
+// RLock calls f with the guarded value while holding the read lock.
+//
+// f may run concurrently with other readers, it must not modify the value
+// and the pointer must not escape f.
+func (self *RWMutex[T]) RLock(f func(*T)) {
+	self.mu.RLock()
+	defer self.mu.RUnlock()
+	f(&self.val)
+}
+
+// TryLock is like [RWMutex.Lock], but gives up if the lock is not acquired without blocking.
+func (self *RWMutex[T]) TryLock(f func(*T)) bool {
+	if !self.mu.TryLock() {
+		return false
+	}
+	defer self.mu.Unlock()
+	f(&self.val)
+	return true
+}
+
+// TryRLock is like [RWMutex.RLock], but gives up if the lock is not acquired without blocking.
+func (self *RWMutex[T]) TryRLock(f func(*T)) bool {
+	if !self.mu.TryRLock() {
+		return false
+	}
+	defer self.mu.RUnlock()
+	f(&self.val)
+	return true
+}
+
+// WithRLock calls f with the value guarded by m while holding the read lock, and returns its result.
+func WithRLock[T, R any](m *RWMutex[T], f func(*T) R) (r R) {
+	m.RLock(func(v *T) { r = f(v) })
+	return
+}
@@ -0,0 +1,55 @@
+package sync
+
+import (
+	"sync"
+)
+
+type onceEntry[V any] struct {
+	mu   sync.Mutex
+	done bool
+	val  V
+}
+
+// OnceMap computes a value for each key exactly once, on first access of the key.
+type OnceMap[K comparable, V any] struct {
+	f     func(K) V
+	inner Map[K, *onceEntry[V]]
+}
+
+// NewOnceMap creates an [OnceMap] computing values by f.
+func NewOnceMap[K comparable, V any](f func(K) V) *OnceMap[K, V] {
+	return &OnceMap[K, V]{f: f}
+}
+
+// Get returns the value of key, computes it if it's the first access of key.
+//
+// Concurrent callers of the same key wait for a single computation.
+// If f panics, the panic is re-raised and the next Get of the key computes again.
+func (self *OnceMap[K, V]) Get(key K) V {
+	e := self.inner.ComputeIfAbsent(key, func(K) *onceEntry[V] { return &onceEntry[V]{} })
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.done {
+		e.val = self.f(key)
+		e.done = true
+	}
+	return e.val
+}
+
+// Load returns the value of key if it has been computed, without computing it.
+func (self *OnceMap[K, V]) Load(key K) (V, bool) {
+	var zero V
+	e, ok := self.inner.Get(key)
+	if !ok {
+		return zero, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.done {
+		return zero, false
+	}
+	return e.val, true
+}
+
+// Delete forgets the value of key, so the next Get of key computes again.
+func (self *OnceMap[K, V]) Delete(key K) { self.inner.Delete(key) }
@@ -0,0 +1,302 @@
+package sync
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Combinators in this file compose plain channels into pipelines,
+// use [ChannelReceiver.Chan] to feed them from a [ChannelReceiver].
+//
+// Each of them closes its output channels once its inputs are closed or ctx is done,
+// and all goroutines it starts exit by then. A consumer which stops reading early
+// must cancel ctx, otherwise the goroutines stay blocked sending to it.
+
+// send sends v to ch unless ctx is done first, and reports whether it's sent.
+func send[T any](ctx context.Context, ch chan<- T, v T) bool {
+	select {
+	case ch <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// receive receives from ch unless ctx is done first,
+// ok is false if ch is closed or ctx is done.
+func receive[T any](ctx context.Context, ch <-chan T) (v T, ok bool) {
+	select {
+	case v, ok = <-ch:
+		return
+	case <-ctx.Done():
+		return
+	}
+}
+
+func readOnly[T any](chs []chan T) []<-chan T {
+	rs := make([]<-chan T, len(chs))
+	for i, ch := range chs {
+		rs[i] = ch
+	}
+	return rs
+}
+
+func makeChans[T any](n int) []chan T {
+	chs := make([]chan T, n)
+	for i := range chs {
+		chs[i] = make(chan T)
+	}
+	return chs
+}
+
+func closeAll[T any](chs []chan T) {
+	for _, ch := range chs {
+		close(ch)
+	}
+}
+
+// Merge fans in many channels into a single channel.
+//
+// Elements of the same input keep their order, elements of different inputs interleave.
+func Merge[T any](ctx context.Context, chs ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(chs))
+	for _, ch := range chs {
+		go func(ch <-chan T) {
+			defer wg.Done()
+			for {
+				v, ok := receive(ctx, ch)
+				if !ok || !send(ctx, out, v) {
+					return
+				}
+			}
+		}(ch)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// FanOut distributes elements of in to n channels, each element is delivered to
+// whichever output is ready to receive first, so slow consumers get less work.
+//
+// If n less than 1, it's set to 1.
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n < 1 {
+		n = 1
+	}
+	outs := makeChans[T](n)
+	for _, out := range outs {
+		go func(out chan T) {
+			defer close(out)
+			for {
+				v, ok := receive(ctx, in)
+				if !ok || !send(ctx, out, v) {
+					return
+				}
+			}
+		}(out)
+	}
+	return readOnly(outs)
+}
+
+// RoundRobin distributes elements of in to n channels in turn,
+// waiting for each output to receive its element before moving to the next one.
+//
+// If n less than 1, it's set to 1.
+func RoundRobin[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n < 1 {
+		n = 1
+	}
+	outs := makeChans[T](n)
+	go func() {
+		defer closeAll(outs)
+		for i := 0; ; i = (i + 1) % n {
+			v, ok := receive(ctx, in)
+			if !ok || !send(ctx, outs[i], v) {
+				return
+			}
+		}
+	}()
+	return readOnly(outs)
+}
+
+// Tee duplicates every element of in to n channels.
+//
+// An element is delivered to all outputs, in whichever order they're ready,
+// before the next one is received, so the slowest consumer sets the pace.
+// If n less than 1, it's set to 1.
+func Tee[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n < 1 {
+		n = 1
+	}
+	outs := makeChans[T](n)
+	go func() {
+		defer closeAll(outs)
+		// the last case is ctx.Done(), a send case is disabled once its output has the element.
+		cases := make([]reflect.SelectCase, n+1)
+		cases[n] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+		for {
+			v, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			for i, out := range outs {
+				cases[i] = reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(out), Send: reflect.ValueOf(&v).Elem()}
+			}
+			for pending := n; pending > 0; pending-- {
+				i, _, _ := reflect.Select(cases)
+				if i == n {
+					return
+				}
+				cases[i].Chan = reflect.Value{}
+			}
+		}
+	}()
+	return readOnly(outs)
+}
+
+// Batch groups elements of in into slices of at most size elements.
+//
+// If window is positive, a batch is also emitted once window has passed since
+// its first element arrived, so a slow input doesn't hold elements back for long.
+// The last partial batch is emitted when in is closed.
+// If size less than 1, it's set to 1.
+func Batch[T any](ctx context.Context, in <-chan T, size int, window time.Duration) <-chan []T {
+	if size < 1 {
+		size = 1
+	}
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var (
+			batch []T
+			timer *time.Timer
+			timeC <-chan time.Time
+		)
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timeC = nil, nil
+			}
+			if len(batch) == 0 {
+				return true
+			}
+			b := batch
+			batch = nil
+			return send(ctx, out, b)
+		}
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				batch = append(batch, v)
+				if len(batch) == 1 && window > 0 {
+					timer = time.NewTimer(window)
+					timeC = timer.C
+				}
+				if len(batch) >= size && !flush() {
+					return
+				}
+			case <-timeC:
+				timer, timeC = nil, nil
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Throttle passes elements of in through, at most one per interval.
+//
+// Elements are delayed rather than dropped, so it also slows down the producer.
+func Throttle[T any](ctx context.Context, in <-chan T, interval time.Duration) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		var next time.Time
+		for {
+			v, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			if d := time.Until(next); d > 0 {
+				timer := time.NewTimer(d)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				}
+			}
+			if !send(ctx, out, v) {
+				return
+			}
+			next = time.Now().Add(interval)
+		}
+	}()
+	return out
+}
+
+// Debounce emits the latest element of in once no new element has arrived for wait,
+// elements superseded within the quiet period are dropped.
+//
+// A pending element is emitted when in is closed.
+func Debounce[T any](ctx context.Context, in <-chan T, wait time.Duration) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		var (
+			latest  T
+			pending bool
+			timer   = time.NewTimer(wait)
+		)
+		timer.Stop()
+		defer timer.Stop()
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if pending {
+						send(ctx, out, latest)
+					}
+					return
+				}
+				latest, pending = v, true
+				timer.Stop()
+				select {
+				case <-timer.C:
+				default:
+				}
+				timer.Reset(wait)
+			case <-timer.C:
+				if pending {
+					pending = false
+					if !send(ctx, out, latest) {
+						return
+					}
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
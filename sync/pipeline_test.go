@@ -0,0 +1,225 @@
+package sync_test
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	xsync "github.com/go-board/std/sync"
+)
+
+// checkGoroutines fails the test if goroutines started by it are still running at cleanup.
+func checkGoroutines(t *testing.T) {
+	n := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > n && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		qt.Check(t, runtime.NumGoroutine() <= n, qt.IsTrue, qt.Commentf("leaked %d goroutines", runtime.NumGoroutine()-n))
+	})
+}
+
+func produce[T any](elems ...T) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, e := range elems {
+			ch <- e
+		}
+	}()
+	return ch
+}
+
+func drain[T any](ch <-chan T) []T {
+	var rs []T
+	for v := range ch {
+		rs = append(rs, v)
+	}
+	return rs
+}
+
+func TestMerge(t *testing.T) {
+	checkGoroutines(t)
+	rs := drain(xsync.Merge(context.Background(), produce(1, 2, 3), produce(4, 5), produce[int]()))
+	sort.Ints(rs)
+	qt.Assert(t, rs, qt.DeepEquals, []int{1, 2, 3, 4, 5})
+}
+
+func TestMerge_Cancel(t *testing.T) {
+	checkGoroutines(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	never := make(chan int)
+	out := xsync.Merge(ctx, never, never)
+	cancel()
+	_, ok := <-out
+	qt.Assert(t, ok, qt.IsFalse)
+}
+
+func TestFanOut(t *testing.T) {
+	checkGoroutines(t)
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 0; i < 100; i++ {
+			in <- i
+		}
+	}()
+	outs := xsync.FanOut(context.Background(), in, 3)
+	var mu sync.Mutex
+	var all []int
+	var wg sync.WaitGroup
+	for _, out := range outs {
+		wg.Add(1)
+		go func(out <-chan int) {
+			defer wg.Done()
+			rs := drain(out)
+			mu.Lock()
+			all = append(all, rs...)
+			mu.Unlock()
+		}(out)
+	}
+	wg.Wait()
+	sort.Ints(all)
+	qt.Assert(t, len(all), qt.Equals, 100)
+	for i, v := range all {
+		qt.Assert(t, v, qt.Equals, i)
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	checkGoroutines(t)
+	outs := xsync.RoundRobin(context.Background(), produce(0, 1, 2, 3, 4), 2)
+	var wg sync.WaitGroup
+	rs := make([][]int, 2)
+	for i, out := range outs {
+		wg.Add(1)
+		go func(i int, out <-chan int) { defer wg.Done(); rs[i] = drain(out) }(i, out)
+	}
+	wg.Wait()
+	qt.Assert(t, rs, qt.DeepEquals, [][]int{{0, 2, 4}, {1, 3}})
+}
+
+func TestTee(t *testing.T) {
+	checkGoroutines(t)
+	outs := xsync.Tee(context.Background(), produce("a", "b", "c"), 2)
+	var wg sync.WaitGroup
+	rs := make([][]string, 2)
+	for i, out := range outs {
+		wg.Add(1)
+		go func(i int, out <-chan string) { defer wg.Done(); rs[i] = drain(out) }(i, out)
+	}
+	wg.Wait()
+	qt.Assert(t, rs, qt.DeepEquals, [][]string{{"a", "b", "c"}, {"a", "b", "c"}})
+}
+
+func TestTee_Cancel(t *testing.T) {
+	checkGoroutines(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int, 3)
+	in <- 1
+	in <- 2
+	in <- 3
+	outs := xsync.Tee(ctx, in, 2)
+	qt.Assert(t, <-outs[0], qt.Equals, 1)
+	cancel()
+	for _, out := range outs {
+		drain(out)
+	}
+}
+
+func TestTee_AnyOrder(t *testing.T) {
+	checkGoroutines(t)
+	outs := xsync.Tee(context.Background(), produce(1, 2), 2)
+	// a single consumer reading the outputs in reverse order doesn't block Tee.
+	qt.Assert(t, <-outs[1], qt.Equals, 1)
+	qt.Assert(t, <-outs[0], qt.Equals, 1)
+	qt.Assert(t, <-outs[1], qt.Equals, 2)
+	qt.Assert(t, <-outs[0], qt.Equals, 2)
+	for _, out := range outs {
+		qt.Assert(t, drain(out), qt.HasLen, 0)
+	}
+}
+
+func TestPipeline_ChannelReceiver(t *testing.T) {
+	checkGoroutines(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s1, r1 := xsync.BufferedChannel[int](3)
+	s2, r2 := xsync.BufferedChannel[int](3)
+	for i := 0; i < 3; i++ {
+		s1.Send(i)
+		select {
+		case s2.Chan() <- i + 10:
+		default:
+			t.Fatal("buffered send blocked")
+		}
+	}
+	batches := xsync.Batch(ctx, xsync.Merge(ctx, r1.Chan(), r2.Chan()), 2, 0)
+	var rs []int
+	for len(rs) < 6 {
+		rs = append(rs, <-batches...)
+	}
+	sort.Ints(rs)
+	qt.Assert(t, rs, qt.DeepEquals, []int{0, 1, 2, 10, 11, 12})
+	// closing a sender doesn't close its channel, cancel stops the pipeline.
+	s1.Close()
+	s2.Close()
+	cancel()
+	drain(batches)
+}
+
+func TestBatch(t *testing.T) {
+	checkGoroutines(t)
+	rs := drain(xsync.Batch(context.Background(), produce(1, 2, 3, 4, 5), 2, 0))
+	qt.Assert(t, rs, qt.DeepEquals, [][]int{{1, 2}, {3, 4}, {5}})
+}
+
+func TestBatch_Window(t *testing.T) {
+	checkGoroutines(t)
+	in := make(chan int)
+	out := xsync.Batch(context.Background(), in, 10, 20*time.Millisecond)
+	go func() {
+		in <- 1
+		in <- 2
+		time.Sleep(60 * time.Millisecond)
+		in <- 3
+		close(in)
+	}()
+	qt.Assert(t, drain(out), qt.DeepEquals, [][]int{{1, 2}, {3}})
+}
+
+func TestThrottle(t *testing.T) {
+	checkGoroutines(t)
+	start := time.Now()
+	rs := drain(xsync.Throttle(context.Background(), produce(1, 2, 3, 4), 10*time.Millisecond))
+	qt.Assert(t, rs, qt.DeepEquals, []int{1, 2, 3, 4})
+	qt.Assert(t, time.Since(start) >= 30*time.Millisecond, qt.IsTrue)
+}
+
+func TestDebounce(t *testing.T) {
+	checkGoroutines(t)
+	in := make(chan int)
+	out := xsync.Debounce(context.Background(), in, 20*time.Millisecond)
+	go func() {
+		in <- 1
+		in <- 2
+		in <- 3
+		time.Sleep(60 * time.Millisecond)
+		in <- 4
+		close(in)
+	}()
+	qt.Assert(t, drain(out), qt.DeepEquals, []int{3, 4})
+}
+
+func TestDebounce_Cancel(t *testing.T) {
+	checkGoroutines(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	out := xsync.Debounce(ctx, make(chan int), time.Millisecond)
+	cancel()
+	qt.Assert(t, drain(out), qt.HasLen, 0)
+}
@@ -9,10 +9,15 @@ import (
 )
 
 // Map is generic version of sync.Map.
-type Map[K any, V any] struct{ inner sync.Map }
+type Map[K any, V any] struct {
+	// inner holds a *V per key, so compare-and-swap compares boxes rather than values,
+	// which works for any V and makes a replaced value distinguishable from an equal one.
+	inner sync.Map
+	rmw   mapLock
+}
 
 func (self *Map[K, V]) Insert(key K, v V) {
-	self.inner.Store(key, v)
+	self.inner.Store(key, &v)
 }
 
 func (self *Map[K, V]) Get(key K) (V, bool) {
@@ -20,20 +25,20 @@ func (self *Map[K, V]) Get(key K) (V, bool) {
 }
 
 func (self *Map[K, V]) GetOptional(key K) optional.Optional[V] {
-	return optional.Map(optional.FromPair(self.inner.Load(key)), func(x any) V { return x.(V) })
+	return optional.Map(optional.FromPair(self.inner.Load(key)), func(x any) V { return *x.(*V) })
 }
 
 func (self *Map[K, V]) GetOrPut(key K, val V) (V, bool) {
-	replaced, ok := self.inner.LoadOrStore(key, val)
+	replaced, ok := self.inner.LoadOrStore(key, &val)
 	if !ok {
 		var t V
 		return t, false
 	}
-	return replaced.(V), true
+	return *replaced.(*V), true
 }
 
 func (self *Map[K, V]) Range(fn func(K, V) bool) {
-	self.inner.Range(func(key, value any) bool { return fn(key.(K), value.(V)) })
+	self.inner.Range(func(key, value any) bool { return fn(key.(K), *value.(*V)) })
 }
 
 func (self *Map[K, V]) Keys() iter.Seq[K] {
@@ -48,6 +53,9 @@ func (self *Map[K, V]) Values() iter.Seq[V] {
 	}
 }
 
+// Iter returns an [iter.Seq2] over the k-v pairs in the map.
+func (self *Map[K, V]) Iter() iter.Seq2[K, V] { return self.Range }
+
 func (self *Map[K, V]) Entries() iter.Seq[tuple.Pair[K, V]] {
 	return func(yield func(tuple.Pair[K, V]) bool) {
 		self.Range(func(k K, v V) bool { return yield(tuple.MakePair(k, v)) })
@@ -58,6 +66,89 @@ func (self *Map[K, V]) Delete(key K) {
 	self.inner.Delete(key)
 }
 
+// LoadAndDelete deletes the value of key, returning the previous value if any.
+func (self *Map[K, V]) LoadAndDelete(key K) (V, bool) {
+	return self.cast(self.inner.LoadAndDelete(key))
+}
+
+// Swap stores the value of key and returns the previous value if any.
+func (self *Map[K, V]) Swap(key K, v V) (V, bool) {
+	return self.cast(self.swap(key, v))
+}
+
+// MapCompareAndSwap stores new for key in m if the value of key equals to old,
+// and reports whether it's stored.
+func MapCompareAndSwap[K any, V comparable](m *Map[K, V], key K, old, new V) bool {
+	return compareAndSwap(m, key, old, new)
+}
+
+// MapCompareAndDelete deletes key from m if its value equals to old, and reports whether it's deleted.
+func MapCompareAndDelete[K any, V comparable](m *Map[K, V], key K, old V) bool {
+	return compareAndDelete(m, key, old)
+}
+
+// ComputeIfAbsent returns the value of key, stores f(key) first if key is absent.
+//
+// f may be called by racing callers more than once, but only one result is stored
+// and returned to all of them.
+func (self *Map[K, V]) ComputeIfAbsent(key K, f func(K) V) V {
+	if v, ok := self.inner.Load(key); ok {
+		return *v.(*V)
+	}
+	v := f(key)
+	stored, _ := self.inner.LoadOrStore(key, &v)
+	return *stored.(*V)
+}
+
+// Compute atomically replaces the value of key with the result of f.
+//
+// f receives the current value and whether key is present, it returns the new value
+// and whether to keep it, key is deleted if not kept. Compute returns what f returned.
+// f may be called more than once under contention, so it must be free of side effects.
+//
+// Compute is atomic with respect to every other write of key. Before go1.20, sync.Map
+// has no compare-and-swap, so it's only atomic with respect to Swap, Compute, Merge and
+// the compare-and-swap functions, which share a lock, but not to Insert and Delete.
+func (self *Map[K, V]) Compute(key K, f func(old V, loaded bool) (V, bool)) (V, bool) {
+	return self.compute(key, f)
+}
+
+// Merge stores v for key if key is absent, otherwise replaces the current value
+// with f(current, v), and returns the stored value.
+//
+// Merge is atomic in the same way as [Map.Compute], and f may be called more than once too.
+func (self *Map[K, V]) Merge(key K, v V, f func(old, new V) V) V {
+	merged, _ := self.Compute(key, func(old V, loaded bool) (V, bool) {
+		if !loaded {
+			return v, true
+		}
+		return f(old, v), true
+	})
+	return merged
+}
+
+// Len returns the number of entries, it takes O(n) time.
+//
+// Under concurrent modification the count may not match any single snapshot of the map.
+func (self *Map[K, V]) Len() int {
+	n := 0
+	self.inner.Range(func(any, any) bool { n++; return true })
+	return n
+}
+
+// Clear deletes all entries.
+func (self *Map[K, V]) Clear() {
+	self.inner.Range(func(key, _ any) bool { self.inner.Delete(key); return true })
+}
+
+func (self *Map[K, V]) cast(v any, ok bool) (V, bool) {
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return *v.(*V), true
+}
+
 // Pool is generic version of sync.Pool
 type Pool[T any] struct{ inner sync.Pool }
 
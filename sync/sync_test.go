@@ -0,0 +1,272 @@
+package sync_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	xsync "github.com/go-board/std/sync"
+)
+
+func TestAtomic(t *testing.T) {
+	var a xsync.Atomic[string]
+	qt.Assert(t, a.Load(), qt.Equals, "")
+	a.Store("a")
+	qt.Assert(t, a.Swap("b"), qt.Equals, "a")
+	qt.Assert(t, xsync.CompareAndSwap(&a, "a", "c"), qt.IsFalse)
+	qt.Assert(t, xsync.CompareAndSwap(&a, "b", "c"), qt.IsTrue)
+	qt.Assert(t, a.Load(), qt.Equals, "c")
+
+	s := xsync.NewAtomic([]int{1})
+	eq := func(x, y []int) bool { return len(x) == len(y) }
+	qt.Assert(t, s.CompareAndSwapFunc([]int{9}, []int{1, 2}, eq), qt.IsTrue)
+	qt.Assert(t, s.Load(), qt.DeepEquals, []int{1, 2})
+}
+
+func TestAtomic_Update(t *testing.T) {
+	a := xsync.NewAtomic(0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				a.Update(func(x int) int { return x + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+	qt.Assert(t, a.Load(), qt.Equals, 800)
+}
+
+func TestMutex(t *testing.T) {
+	m := xsync.NewMutex(map[string]int{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Lock(func(v *map[string]int) { (*v)["x"]++ })
+		}()
+	}
+	wg.Wait()
+	qt.Assert(t, xsync.WithLock(m, func(v *map[string]int) int { return (*v)["x"] }), qt.Equals, 8)
+
+	m.Lock(func(*map[string]int) {
+		qt.Assert(t, m.TryLock(func(*map[string]int) {}), qt.IsFalse)
+	})
+	qt.Assert(t, m.TryLock(func(*map[string]int) {}), qt.IsTrue)
+}
+
+func TestRWMutex(t *testing.T) {
+	m := xsync.NewRWMutex([]int{1, 2})
+	m.Lock(func(v *[]int) { *v = append(*v, 3) })
+	qt.Assert(t, xsync.WithRLock(m, func(v *[]int) int { return len(*v) }), qt.Equals, 3)
+	m.RLock(func(*[]int) {
+		qt.Assert(t, m.TryRLock(func(*[]int) {}), qt.IsTrue)
+		qt.Assert(t, m.TryLock(func(*[]int) {}), qt.IsFalse)
+	})
+}
+
+func TestOnceMap(t *testing.T) {
+	var calls atomic.Int32
+	m := xsync.NewOnceMap(func(k int) int { calls.Add(1); time.Sleep(time.Millisecond); return k * k })
+	_, ok := m.Load(3)
+	qt.Assert(t, ok, qt.IsFalse)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() { defer wg.Done(); qt.Check(t, m.Get(3), qt.Equals, 9) }()
+	}
+	wg.Wait()
+	qt.Assert(t, calls.Load(), qt.Equals, int32(1))
+	v, ok := m.Load(3)
+	qt.Assert(t, ok, qt.IsTrue)
+	qt.Assert(t, v, qt.Equals, 9)
+	m.Delete(3)
+	m.Get(3)
+	qt.Assert(t, calls.Load(), qt.Equals, int32(2))
+
+	p := xsync.NewOnceMap(func(k int) int {
+		if calls.Add(1) == 3 {
+			panic("oops")
+		}
+		return k
+	})
+	qt.Assert(t, func() { p.Get(1) }, qt.PanicMatches, "oops")
+	qt.Assert(t, p.Get(1), qt.Equals, 1)
+}
+
+func TestGroup(t *testing.T) {
+	g := xsync.NewGroup[int](context.Background(), 2)
+	var running, peak atomic.Int32
+	for i := 0; i < 6; i++ {
+		i := i
+		g.Go(func(ctx context.Context) (int, error) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return i * 10, nil
+		})
+	}
+	rs, err := g.Wait()
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, rs, qt.DeepEquals, []int{0, 10, 20, 30, 40, 50})
+	qt.Assert(t, peak.Load() <= 2, qt.IsTrue)
+}
+
+func TestGroup_Error(t *testing.T) {
+	boom := errors.New("boom")
+	g := xsync.NewGroup[string](context.Background(), 0)
+	g.Go(func(ctx context.Context) (string, error) { return "", boom })
+	g.Go(func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	_, err := g.Wait()
+	qt.Assert(t, err, qt.Equals, boom)
+}
+
+func TestGroup_TryGo(t *testing.T) {
+	g := xsync.NewGroup[int](context.Background(), 1)
+	release := make(chan struct{})
+	qt.Assert(t, g.TryGo(func(context.Context) (int, error) { <-release; return 1, nil }), qt.IsTrue)
+	qt.Assert(t, g.TryGo(func(context.Context) (int, error) { return 2, nil }), qt.IsFalse)
+	close(release)
+	rs, err := g.Wait()
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, rs, qt.DeepEquals, []int{1})
+}
+
+func TestMap_Swap(t *testing.T) {
+	var m xsync.Map[string, int]
+	_, ok := m.Swap("a", 1)
+	qt.Assert(t, ok, qt.IsFalse)
+	prev, ok := m.Swap("a", 2)
+	qt.Assert(t, ok, qt.IsTrue)
+	qt.Assert(t, prev, qt.Equals, 1)
+
+	qt.Assert(t, xsync.MapCompareAndSwap(&m, "a", 1, 3), qt.IsFalse)
+	qt.Assert(t, xsync.MapCompareAndSwap(&m, "a", 2, 3), qt.IsTrue)
+	qt.Assert(t, xsync.MapCompareAndSwap(&m, "b", 0, 3), qt.IsFalse)
+	qt.Assert(t, xsync.MapCompareAndDelete(&m, "a", 2), qt.IsFalse)
+	qt.Assert(t, xsync.MapCompareAndDelete(&m, "a", 3), qt.IsTrue)
+	qt.Assert(t, m.Len(), qt.Equals, 0)
+
+	m.Insert("x", 1)
+	v, ok := m.LoadAndDelete("x")
+	qt.Assert(t, ok, qt.IsTrue)
+	qt.Assert(t, v, qt.Equals, 1)
+	_, ok = m.LoadAndDelete("x")
+	qt.Assert(t, ok, qt.IsFalse)
+}
+
+func TestMap_Compute(t *testing.T) {
+	var m xsync.Map[string, int]
+	qt.Assert(t, m.ComputeIfAbsent("a", func(string) int { return 1 }), qt.Equals, 1)
+	qt.Assert(t, m.ComputeIfAbsent("a", func(string) int { return 2 }), qt.Equals, 1)
+
+	v, ok := m.Compute("a", func(old int, loaded bool) (int, bool) { return old + 10, true })
+	qt.Assert(t, ok, qt.IsTrue)
+	qt.Assert(t, v, qt.Equals, 11)
+	_, ok = m.Compute("a", func(int, bool) (int, bool) { return 0, false })
+	qt.Assert(t, ok, qt.IsFalse)
+	_, ok = m.Get("a")
+	qt.Assert(t, ok, qt.IsFalse)
+	_, ok = m.Compute("b", func(int, bool) (int, bool) { return 0, false })
+	qt.Assert(t, ok, qt.IsFalse)
+	qt.Assert(t, m.Len(), qt.Equals, 0)
+}
+
+func TestMap_ComputeAnyValue(t *testing.T) {
+	var f xsync.Map[string, float64]
+	f.Insert("nan", math.NaN())
+	v := f.Merge("nan", 1, func(old, new float64) float64 { return new })
+	qt.Assert(t, v, qt.Equals, 1.0)
+	qt.Assert(t, xsync.MapCompareAndSwap(&f, "x", math.NaN(), 1), qt.IsFalse)
+
+	var s xsync.Map[string, []int]
+	s.Merge("a", []int{1}, func(old, new []int) []int { return append(old, new...) })
+	s.Merge("a", []int{2}, func(old, new []int) []int { return append(old, new...) })
+	got, _ := s.Get("a")
+	qt.Assert(t, got, qt.DeepEquals, []int{1, 2})
+}
+
+func TestMap_Merge(t *testing.T) {
+	var m xsync.Map[string, int]
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Merge("hits", 1, func(old, new int) int { return old + new })
+			}
+		}()
+	}
+	wg.Wait()
+	v, _ := m.Get("hits")
+	qt.Assert(t, v, qt.Equals, 800)
+
+	m.Insert("x", 1)
+	qt.Assert(t, m.Len(), qt.Equals, 2)
+	m.Clear()
+	qt.Assert(t, m.Len(), qt.Equals, 0)
+}
+
+func TestMap_ComputeRacingWriters(t *testing.T) {
+	var m xsync.Map[string, int]
+	m.Insert("n", 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if i%2 == 0 {
+					m.Merge("n", 1, func(old, new int) int {
+						// let writers in between reading old and storing the sum.
+						runtime.Gosched()
+						return old + new
+					})
+					continue
+				}
+				for {
+					old, _ := m.Get("n")
+					if xsync.MapCompareAndSwap(&m, "n", old, old+1) {
+						break
+					}
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	v, _ := m.Get("n")
+	qt.Assert(t, v, qt.Equals, 800)
+}
+
+func TestMap_Iter(t *testing.T) {
+	var m xsync.Map[string, int]
+	m.Insert("a", 1)
+	m.Insert("b", 2)
+	m.Insert("c", 3)
+	got := map[string]int{}
+	m.Iter()(func(k string, v int) bool { got[k] = v; return true })
+	qt.Assert(t, got, qt.DeepEquals, map[string]int{"a": 1, "b": 2, "c": 3})
+
+	n := 0
+	m.Iter()(func(string, int) bool { n++; return false })
+	qt.Assert(t, n, qt.Equals, 1)
+}
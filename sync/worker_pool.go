@@ -0,0 +1,396 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/go-board/std/result"
+)
+
+// ErrPoolClosed is returned by submitting to a [WorkerPool] which is shut down,
+// it's also the result of queued tasks abandoned by a forced shutdown
+// and of scheduled tasks not due yet by the shutdown.
+var ErrPoolClosed = errors.New("sync: worker pool closed")
+
+// errQueueFull tells TrySubmit that a task isn't queued without blocking.
+var errQueueFull = errors.New("sync: worker pool queue full")
+
+// PanicError is the error a task results in when it panicked.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("sync: task panicked: %v\n\n%s", p.Value, p.Stack)
+}
+
+// PoolHooks observes tasks and workers of a [WorkerPool], nil hooks are skipped.
+//
+// Hooks are called on worker goroutines, so they must be safe for concurrent use and cheap.
+type PoolHooks struct {
+	// TaskStarted is called when a worker picks up a task, with the time it waited in the queue.
+	TaskStarted func(wait time.Duration)
+	// TaskDone is called when a task finishes, with the time it ran and its error, nil on success.
+	TaskDone func(elapsed time.Duration, err error)
+	// WorkersChanged is called with the new number of workers whenever it changes.
+	WorkersChanged func(n int)
+}
+
+// WorkerPoolConfig configures a [WorkerPool].
+type WorkerPoolConfig struct {
+	// Workers is the number of workers kept running, it's set to 1 if less than 1.
+	Workers int
+	// MaxWorkers bounds the number of workers of an elastic pool, extra workers are started
+	// when the queue is full and exit once idle for IdleTimeout.
+	// The pool has a fixed size if MaxWorkers is not greater than Workers.
+	MaxWorkers int
+	// IdleTimeout is how long an extra worker waits for a task before it exits, defaults to a minute.
+	IdleTimeout time.Duration
+	// QueueSize is the number of tasks waiting for a worker before submitting blocks.
+	QueueSize int
+	// TaskTimeout is the deadline of each task counted from when it starts, no deadline if zero.
+	TaskTimeout time.Duration
+	// Hooks observes the pool, e.g. to record metrics.
+	Hooks PoolHooks
+}
+
+type task[Req, Resp any] struct {
+	ctx    context.Context
+	req    Req
+	out    *ChannelSender[result.Result[Resp]]
+	queued time.Time
+}
+
+func newTask[Req, Resp any](ctx context.Context, req Req) (*task[Req, Resp], *ChannelReceiver[result.Result[Resp]]) {
+	// buffered, so completing a task never blocks on its submitter.
+	out, in := BufferedChannel[result.Result[Resp]](1)
+	return &task[Req, Resp]{ctx: ctx, req: req, out: out, queued: time.Now()}, in
+}
+
+// WorkerPool runs a handler on a bounded set of worker goroutines.
+//
+// Tasks wait for a worker in a queue made by [BufferedChannel], and each submitted request
+// gets a [ChannelReceiver] of its response, either at once with [WorkerPool.Submit] or
+// after a delay with [WorkerPool.Schedule]. A handler which panics results in a
+// [*PanicError] instead of crashing the process.
+//
+// Example:
+//
+//	pool := NewWorkerPool(WorkerPoolConfig{Workers: 4, QueueSize: 16}, func(ctx context.Context, url string) result.Result[int] {
+//		return fetchSize(ctx, url)
+//	})
+//	defer pool.Shutdown(context.Background())
+//	rx, err := pool.Submit(ctx, "https://go.dev")
+//	size, _ := rx.Receive()
+type WorkerPool[Req, Resp any] struct {
+	handler func(context.Context, Req) result.Result[Resp]
+	cfg     WorkerPoolConfig
+	queue   *ChannelSender[*task[Req, Resp]]
+	tasks   *ChannelReceiver[*task[Req, Resp]]
+	// quit is closed when shutdown starts, stopped once no one sends to the queue any more,
+	// then workers drain the queue and exit.
+	quit    chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+	// wg tracks workers and scheduled tasks not due yet.
+	wg sync.WaitGroup
+
+	// sending is held for reading while submitting, so stopped is only closed once no one sends.
+	sending sync.RWMutex
+
+	mu      sync.Mutex
+	workers int
+	aborted bool
+	running map[*task[Req, Resp]]context.CancelFunc
+}
+
+// NewWorkerPool creates a [WorkerPool] running handler and starts its workers.
+func NewWorkerPool[Req, Resp any](cfg WorkerPoolConfig, handler func(context.Context, Req) result.Result[Resp]) *WorkerPool[Req, Resp] {
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+	if cfg.MaxWorkers < cfg.Workers {
+		cfg.MaxWorkers = cfg.Workers
+	}
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = time.Minute
+	}
+	if cfg.QueueSize < 0 {
+		cfg.QueueSize = 0
+	}
+	queue, tasks := BufferedChannel[*task[Req, Resp]](cfg.QueueSize)
+	p := &WorkerPool[Req, Resp]{
+		handler: handler,
+		cfg:     cfg,
+		queue:   queue,
+		tasks:   tasks,
+		quit:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		running: make(map[*task[Req, Resp]]context.CancelFunc),
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := 0; i < cfg.Workers; i++ {
+		p.spawnLocked()
+	}
+	return p
+}
+
+func (self *WorkerPool[Req, Resp]) spawnLocked() {
+	self.workers++
+	self.wg.Add(1)
+	go self.work()
+	if self.cfg.Hooks.WorkersChanged != nil {
+		self.cfg.Hooks.WorkersChanged(self.workers)
+	}
+}
+
+// grow starts an extra worker if the pool is elastic and below its maximum.
+func (self *WorkerPool[Req, Resp]) grow() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	if self.workers < self.cfg.MaxWorkers {
+		self.spawnLocked()
+	}
+}
+
+// retire stops counting the calling worker, unless it's needed to keep the minimum.
+func (self *WorkerPool[Req, Resp]) retire(force bool) bool {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	if !force && self.workers <= self.cfg.Workers {
+		return false
+	}
+	self.workers--
+	if self.cfg.Hooks.WorkersChanged != nil {
+		self.cfg.Hooks.WorkersChanged(self.workers)
+	}
+	return true
+}
+
+func (self *WorkerPool[Req, Resp]) work() {
+	defer self.wg.Done()
+	var timer *time.Timer
+	var idle <-chan time.Time
+	if self.cfg.MaxWorkers > self.cfg.Workers {
+		timer = time.NewTimer(self.cfg.IdleTimeout)
+		defer timer.Stop()
+		idle = timer.C
+	}
+	for {
+		select {
+		case t := <-self.tasks.Chan():
+			self.run(t)
+			if timer != nil {
+				timer.Stop()
+				select {
+				case <-timer.C:
+				default:
+				}
+				timer.Reset(self.cfg.IdleTimeout)
+			}
+		case <-self.stopped:
+			for {
+				select {
+				case t := <-self.tasks.Chan():
+					self.run(t)
+				default:
+					self.retire(true)
+					return
+				}
+			}
+		case <-idle:
+			if self.retire(false) {
+				return
+			}
+			timer.Reset(self.cfg.IdleTimeout)
+		}
+	}
+}
+
+func (self *WorkerPool[Req, Resp]) run(t *task[Req, Resp]) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if self.cfg.TaskTimeout > 0 {
+		ctx, cancel = context.WithTimeout(t.ctx, self.cfg.TaskTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(t.ctx)
+	}
+	defer cancel()
+
+	self.mu.Lock()
+	if self.aborted {
+		self.mu.Unlock()
+		t.out.Send(result.Err[Resp](ErrPoolClosed))
+		return
+	}
+	self.running[t] = cancel
+	self.mu.Unlock()
+	defer func() {
+		self.mu.Lock()
+		delete(self.running, t)
+		self.mu.Unlock()
+	}()
+
+	start := time.Now()
+	if self.cfg.Hooks.TaskStarted != nil {
+		self.cfg.Hooks.TaskStarted(start.Sub(t.queued))
+	}
+	r := self.call(ctx, t.req)
+	if self.cfg.Hooks.TaskDone != nil {
+		self.cfg.Hooks.TaskDone(time.Since(start), r.ErrorOr(nil))
+	}
+	t.out.Send(r)
+}
+
+func (self *WorkerPool[Req, Resp]) call(ctx context.Context, req Req) (r result.Result[Resp]) {
+	defer func() {
+		if p := recover(); p != nil {
+			r = result.Err[Resp](&PanicError{Value: p, Stack: debug.Stack()})
+		}
+	}()
+	return self.handler(ctx, req)
+}
+
+// enqueue puts t into the queue, if block is false it gives up with errQueueFull
+// instead of waiting for room.
+func (self *WorkerPool[Req, Resp]) enqueue(t *task[Req, Resp], block bool) error {
+	self.sending.RLock()
+	defer self.sending.RUnlock()
+	select {
+	case <-self.quit:
+		return ErrPoolClosed
+	default:
+	}
+	t.queued = time.Now()
+	select {
+	case self.queue.Chan() <- t:
+		return nil
+	default:
+	}
+	self.grow()
+	if !block {
+		select {
+		case self.queue.Chan() <- t:
+			return nil
+		default:
+			return errQueueFull
+		}
+	}
+	select {
+	case self.queue.Chan() <- t:
+		return nil
+	case <-t.ctx.Done():
+		return t.ctx.Err()
+	case <-self.quit:
+		return ErrPoolClosed
+	}
+}
+
+// Submit queues req and returns the [ChannelReceiver] which receives its response once done.
+//
+// It blocks while the queue is full and no extra worker can be started,
+// returns ctx.Err() if ctx is done first, or [ErrPoolClosed] if the pool is shut down.
+// The task runs with a context derived from ctx, so canceling ctx cancels the task.
+func (self *WorkerPool[Req, Resp]) Submit(ctx context.Context, req Req) (*ChannelReceiver[result.Result[Resp]], error) {
+	t, rx := newTask[Req, Resp](ctx, req)
+	if err := self.enqueue(t, true); err != nil {
+		return nil, err
+	}
+	return rx, nil
+}
+
+// TrySubmit is like [WorkerPool.Submit], but gives up instead of blocking,
+// it reports whether req is queued.
+func (self *WorkerPool[Req, Resp]) TrySubmit(ctx context.Context, req Req) (*ChannelReceiver[result.Result[Resp]], bool) {
+	t, rx := newTask[Req, Resp](ctx, req)
+	if err := self.enqueue(t, false); err != nil {
+		return nil, false
+	}
+	return rx, true
+}
+
+// Schedule submits req once delay has passed, and returns the [ChannelReceiver] which
+// receives its response once done.
+//
+// Submitting happens as with [WorkerPool.Submit], if it fails, e.g. ctx is done before
+// the task is due, the receiver gets the error. Shutdown doesn't wait for tasks which
+// are not due yet, they result in [ErrPoolClosed].
+// It returns [ErrPoolClosed] if the pool is shut down.
+func (self *WorkerPool[Req, Resp]) Schedule(ctx context.Context, delay time.Duration, req Req) (*ChannelReceiver[result.Result[Resp]], error) {
+	self.sending.RLock()
+	defer self.sending.RUnlock()
+	select {
+	case <-self.quit:
+		return nil, ErrPoolClosed
+	default:
+	}
+	t, rx := newTask[Req, Resp](ctx, req)
+	self.wg.Add(1)
+	go func() {
+		defer self.wg.Done()
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		var err error
+		select {
+		case <-timer.C:
+			err = self.enqueue(t, true)
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-self.quit:
+			err = ErrPoolClosed
+		}
+		if err != nil {
+			t.out.Send(result.Err[Resp](err))
+		}
+	}()
+	return rx, nil
+}
+
+// Workers returns the number of running workers.
+func (self *WorkerPool[Req, Resp]) Workers() int {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.workers
+}
+
+// Pending returns the number of tasks waiting in the queue.
+func (self *WorkerPool[Req, Resp]) Pending() int { return len(self.tasks.Chan()) }
+
+// Shutdown stops accepting tasks and waits for the queued and running ones to finish.
+//
+// If ctx is done first, running tasks are canceled, tasks still queued result in
+// [ErrPoolClosed] without running, and Shutdown returns ctx.Err() without waiting further.
+// It's safe to call Shutdown more than once.
+func (self *WorkerPool[Req, Resp]) Shutdown(ctx context.Context) error {
+	self.once.Do(func() {
+		// wake up blocked submitters before waiting for them to leave.
+		close(self.quit)
+		self.queue.Close()
+		self.sending.Lock()
+		close(self.stopped)
+		self.sending.Unlock()
+	})
+	done := make(chan struct{})
+	go func() {
+		self.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		self.mu.Lock()
+		self.aborted = true
+		for _, cancel := range self.running {
+			cancel()
+		}
+		self.mu.Unlock()
+		return ctx.Err()
+	}
+}
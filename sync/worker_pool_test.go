@@ -0,0 +1,177 @@
+package sync_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/go-board/std/result"
+	xsync "github.com/go-board/std/sync"
+)
+
+func await[T any](rx *xsync.ChannelReceiver[result.Result[T]]) result.Result[T] {
+	r, _ := rx.Receive()
+	return r
+}
+
+func double(ctx context.Context, x int) result.Result[int] { return result.Ok(x * 2) }
+
+func TestWorkerPool(t *testing.T) {
+	checkGoroutines(t)
+	var done atomic.Int32
+	pool := xsync.NewWorkerPool(xsync.WorkerPoolConfig{
+		Workers:   2,
+		QueueSize: 4,
+		Hooks:     xsync.PoolHooks{TaskDone: func(time.Duration, error) { done.Add(1) }},
+	}, double)
+	futs := make([]*xsync.ChannelReceiver[result.Result[int]], 10)
+	for i := range futs {
+		fut, err := pool.Submit(context.Background(), i)
+		qt.Assert(t, err, qt.IsNil)
+		futs[i] = fut
+	}
+	for i, fut := range futs {
+		qt.Assert(t, await(fut).Value(), qt.Equals, i*2)
+	}
+	qt.Assert(t, pool.Shutdown(context.Background()), qt.IsNil)
+	qt.Assert(t, done.Load(), qt.Equals, int32(10))
+	qt.Assert(t, pool.Workers(), qt.Equals, 0)
+
+	_, err := pool.Submit(context.Background(), 1)
+	qt.Assert(t, err, qt.Equals, xsync.ErrPoolClosed)
+	_, ok := pool.TrySubmit(context.Background(), 1)
+	qt.Assert(t, ok, qt.IsFalse)
+}
+
+func TestWorkerPool_Panic(t *testing.T) {
+	checkGoroutines(t)
+	pool := xsync.NewWorkerPool(xsync.WorkerPoolConfig{}, func(ctx context.Context, x int) result.Result[int] {
+		if x == 0 {
+			panic("boom")
+		}
+		return result.Ok(x)
+	})
+	defer pool.Shutdown(context.Background())
+	fut, _ := pool.Submit(context.Background(), 0)
+	var pe *xsync.PanicError
+	qt.Assert(t, errors.As(await(fut).Error(), &pe), qt.IsTrue)
+	qt.Assert(t, pe.Value, qt.Equals, "boom")
+	// the worker survives the panic.
+	fut, _ = pool.Submit(context.Background(), 1)
+	qt.Assert(t, await(fut).Value(), qt.Equals, 1)
+}
+
+func TestWorkerPool_TaskTimeout(t *testing.T) {
+	checkGoroutines(t)
+	pool := xsync.NewWorkerPool(xsync.WorkerPoolConfig{TaskTimeout: 10 * time.Millisecond}, func(ctx context.Context, x int) result.Result[int] {
+		<-ctx.Done()
+		return result.Err[int](ctx.Err())
+	})
+	defer pool.Shutdown(context.Background())
+	fut, _ := pool.Submit(context.Background(), 0)
+	qt.Assert(t, await(fut).Error(), qt.Equals, context.DeadlineExceeded)
+}
+
+func TestWorkerPool_Elastic(t *testing.T) {
+	checkGoroutines(t)
+	release := make(chan struct{})
+	pool := xsync.NewWorkerPool(xsync.WorkerPoolConfig{Workers: 1, MaxWorkers: 3, IdleTimeout: 10 * time.Millisecond}, func(ctx context.Context, x int) result.Result[int] {
+		<-release
+		return result.Ok(x)
+	})
+	defer pool.Shutdown(context.Background())
+	var futs []*xsync.ChannelReceiver[result.Result[int]]
+	for i := 0; i < 3; i++ {
+		fut, err := pool.Submit(context.Background(), i)
+		qt.Assert(t, err, qt.IsNil)
+		futs = append(futs, fut)
+	}
+	qt.Assert(t, pool.Workers(), qt.Equals, 3)
+	_, ok := pool.TrySubmit(context.Background(), 3)
+	qt.Assert(t, ok, qt.IsFalse)
+
+	close(release)
+	for _, fut := range futs {
+		qt.Assert(t, await(fut).IsOk(), qt.IsTrue)
+	}
+	deadline := time.Now().Add(time.Second)
+	for pool.Workers() > 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	qt.Assert(t, pool.Workers(), qt.Equals, 1)
+}
+
+func TestWorkerPool_SubmitCancel(t *testing.T) {
+	checkGoroutines(t)
+	release := make(chan struct{})
+	pool := xsync.NewWorkerPool(xsync.WorkerPoolConfig{}, func(ctx context.Context, x int) result.Result[int] {
+		<-release
+		return result.Ok(x)
+	})
+	defer pool.Shutdown(context.Background())
+	defer close(release)
+	_, err := pool.Submit(context.Background(), 0)
+	qt.Assert(t, err, qt.IsNil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = pool.Submit(ctx, 1)
+	qt.Assert(t, err, qt.Equals, context.DeadlineExceeded)
+}
+
+func TestWorkerPool_ForcedShutdown(t *testing.T) {
+	checkGoroutines(t)
+	pool := xsync.NewWorkerPool(xsync.WorkerPoolConfig{QueueSize: 1}, func(ctx context.Context, x int) result.Result[int] {
+		<-ctx.Done()
+		return result.Err[int](ctx.Err())
+	})
+	running, _ := pool.Submit(context.Background(), 0)
+	queued, _ := pool.Submit(context.Background(), 1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	qt.Assert(t, pool.Shutdown(ctx), qt.Equals, context.DeadlineExceeded)
+	qt.Assert(t, await(running).Error(), qt.Equals, context.Canceled)
+	qt.Assert(t, await(queued).Error(), qt.Equals, xsync.ErrPoolClosed)
+}
+
+func TestWorkerPool_GracefulShutdown(t *testing.T) {
+	checkGoroutines(t)
+	pool := xsync.NewWorkerPool(xsync.WorkerPoolConfig{QueueSize: 8}, func(ctx context.Context, x int) result.Result[int] {
+		time.Sleep(time.Millisecond)
+		return result.Ok(x)
+	})
+	var futs []*xsync.ChannelReceiver[result.Result[int]]
+	for i := 0; i < 8; i++ {
+		fut, _ := pool.Submit(context.Background(), i)
+		futs = append(futs, fut)
+	}
+	qt.Assert(t, pool.Shutdown(context.Background()), qt.IsNil)
+	for i, fut := range futs {
+		qt.Assert(t, await(fut).Value(), qt.Equals, i)
+	}
+}
+
+func TestWorkerPool_Schedule(t *testing.T) {
+	checkGoroutines(t)
+	pool := xsync.NewWorkerPool(xsync.WorkerPoolConfig{}, double)
+	start := time.Now()
+	rx, err := pool.Schedule(context.Background(), 20*time.Millisecond, 21)
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, await(rx).Value(), qt.Equals, 42)
+	qt.Assert(t, time.Since(start) >= 20*time.Millisecond, qt.IsTrue)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled, err := pool.Schedule(ctx, time.Hour, 1)
+	qt.Assert(t, err, qt.IsNil)
+	cancel()
+	qt.Assert(t, await(canceled).Error(), qt.Equals, context.Canceled)
+
+	later, err := pool.Schedule(context.Background(), time.Hour, 1)
+	qt.Assert(t, err, qt.IsNil)
+	qt.Assert(t, pool.Shutdown(context.Background()), qt.IsNil)
+	qt.Assert(t, await(later).Error(), qt.Equals, xsync.ErrPoolClosed)
+	_, err = pool.Schedule(context.Background(), 0, 1)
+	qt.Assert(t, err, qt.Equals, xsync.ErrPoolClosed)
+}